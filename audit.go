@@ -0,0 +1,278 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"time"
+)
+
+// AuditRecord describes a single interaction with a broker performed through
+// a client returned by NewAuditingClient.
+type AuditRecord struct {
+	// Operation is the name of the Client method called, such as
+	// "ProvisionInstance" or "Bind".
+	Operation string
+	// InstanceID is the ID of the instance the operation is about, if any.
+	// For ProvisionInstance, it is the InstanceID of the response when the
+	// request had none, that is an ID generated by the client.
+	InstanceID string
+	// BindingID is the ID of the binding the operation is about, if any.
+	BindingID string
+	// OriginatingIdentity is the originating identity sent with the request,
+	// if any.
+	OriginatingIdentity *OriginatingIdentity
+	// Timestamp is when the operation started.
+	Timestamp time.Time
+	// Duration is how long the operation took.
+	Duration time.Duration
+	// StatusCode is the HTTP status code of the broker's error response, if
+	// the operation failed with an HTTPStatusCodeError, and zero otherwise.
+	StatusCode int
+	// Error is the error the operation returned, if any.
+	Error error
+}
+
+// AuditSink receives the records of an auditing client.
+type AuditSink interface {
+	// Record is called once per operation, after it completes.  It is
+	// called concurrently if the client is used concurrently.
+	Record(record AuditRecord)
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(record AuditRecord)
+
+// Record calls f.
+func (f AuditSinkFunc) Record(record AuditRecord) {
+	f(record)
+}
+
+// NewAuditingClient returns a Client that performs every operation with
+// inner and then passes a record of it to sink: which operation, about which
+// instance and binding, on behalf of which originating identity, when and
+// with which result.  The methods of ConfigurationReader do not contact the
+// broker and are not recorded.
+func NewAuditingClient(inner Client, sink AuditSink) Client {
+	return &auditingClient{
+		inner: inner,
+		sink:  sink,
+		clock: time.Now,
+	}
+}
+
+type auditingClient struct {
+	inner Client
+	sink  AuditSink
+	clock func() time.Time
+}
+
+var _ Client = &auditingClient{}
+var _ BindingLister = &auditingClient{}
+var _ InstanceChecker = &auditingClient{}
+
+// record passes a record of the operation started at the given time, which
+// returned err, to the sink.
+func (c *auditingClient) record(record AuditRecord, started time.Time, err error) {
+	record.Timestamp = started
+	record.Duration = c.clock().Sub(started)
+	record.Error = err
+	if statusCodeError, ok := IsHTTPError(err); ok {
+		record.StatusCode = statusCodeError.StatusCode
+	}
+	c.sink.Record(record)
+}
+
+func (c *auditingClient) GetCatalog() (*CatalogResponse, error) {
+	started := c.clock()
+	response, err := c.inner.GetCatalog()
+	c.record(AuditRecord{Operation: "GetCatalog"}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, error) {
+	started := c.clock()
+	response, err := c.inner.ProvisionInstance(r)
+	instanceID := r.InstanceID
+	if instanceID == "" && response != nil {
+		instanceID = response.InstanceID
+	}
+	c.record(AuditRecord{
+		Operation:           "ProvisionInstance",
+		InstanceID:          instanceID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceResponse, error) {
+	started := c.clock()
+	response, err := c.inner.UpdateInstance(r)
+	c.record(AuditRecord{
+		Operation:           "UpdateInstance",
+		InstanceID:          r.InstanceID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionResponse, error) {
+	started := c.clock()
+	response, err := c.inner.DeprovisionInstance(r)
+	c.record(AuditRecord{
+		Operation:           "DeprovisionInstance",
+		InstanceID:          r.InstanceID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error) {
+	started := c.clock()
+	response, err := c.inner.GetInstance(r)
+	c.record(AuditRecord{
+		Operation:  "GetInstance",
+		InstanceID: r.InstanceID,
+	}, started, err)
+	return response, err
+}
+
+// InstanceExists audits the instance check of the inner client, and returns
+// an OperationNotAllowedError, without recording it, if the inner client is
+// not an InstanceChecker.
+func (c *auditingClient) InstanceExists(ctx context.Context, instanceID string) (bool, error) {
+	checker, ok := c.inner.(InstanceChecker)
+	if !ok {
+		return false, OperationNotAllowedError{reason: "InstanceExists is not supported by the audited client"}
+	}
+
+	started := c.clock()
+	exists, err := checker.InstanceExists(ctx, instanceID)
+	c.record(AuditRecord{
+		Operation:           "InstanceExists",
+		InstanceID:          instanceID,
+		OriginatingIdentity: OriginatingIdentityFromContext(ctx),
+	}, started, err)
+	return exists, err
+}
+
+func (c *auditingClient) PollLastOperation(r *LastOperationRequest) (*LastOperationResponse, error) {
+	started := c.clock()
+	response, err := c.inner.PollLastOperation(r)
+	c.record(AuditRecord{
+		Operation:           "PollLastOperation",
+		InstanceID:          r.InstanceID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) PollBindingLastOperation(r *BindingLastOperationRequest) (*LastOperationResponse, error) {
+	started := c.clock()
+	response, err := c.inner.PollBindingLastOperation(r)
+	c.record(AuditRecord{
+		Operation:           "PollBindingLastOperation",
+		InstanceID:          r.InstanceID,
+		BindingID:           r.BindingID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) Bind(r *BindRequest) (*BindResponse, error) {
+	started := c.clock()
+	response, err := c.inner.Bind(r)
+	c.record(AuditRecord{
+		Operation:           "Bind",
+		InstanceID:          r.InstanceID,
+		BindingID:           r.BindingID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
+	started := c.clock()
+	response, err := c.inner.Unbind(r)
+	c.record(AuditRecord{
+		Operation:           "Unbind",
+		InstanceID:          r.InstanceID,
+		BindingID:           r.BindingID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) GetBinding(r *GetBindingRequest) (*GetBindingResponse, error) {
+	started := c.clock()
+	response, err := c.inner.GetBinding(r)
+	c.record(AuditRecord{
+		Operation:  "GetBinding",
+		InstanceID: r.InstanceID,
+		BindingID:  r.BindingID,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) RotateBinding(r *RotateBindingRequest) (*BindResponse, error) {
+	started := c.clock()
+	response, err := c.inner.RotateBinding(r)
+	c.record(AuditRecord{
+		Operation:           "RotateBinding",
+		InstanceID:          r.InstanceID,
+		BindingID:           r.BindingID,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}, started, err)
+	return response, err
+}
+
+// GetBindings audits the listing of bindings of the inner client, and
+// returns a GetBindingsNotSupportedError, without recording it, if the
+// inner client is not a BindingLister.
+func (c *auditingClient) GetBindings(instanceID string) ([]GetBindingResponse, error) {
+	lister, ok := c.inner.(BindingLister)
+	if !ok {
+		return nil, GetBindingsNotSupportedError{}
+	}
+
+	started := c.clock()
+	response, err := lister.GetBindings(instanceID)
+	c.record(AuditRecord{
+		Operation:  "GetBindings",
+		InstanceID: instanceID,
+	}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) GetStatus() (*GetStatusResponse, error) {
+	started := c.clock()
+	response, err := c.inner.GetStatus()
+	c.record(AuditRecord{Operation: "GetStatus"}, started, err)
+	return response, err
+}
+
+func (c *auditingClient) BrokerURL() string {
+	return c.inner.BrokerURL()
+}
+
+func (c *auditingClient) BrokerAPIVersion() APIVersion {
+	return c.inner.BrokerAPIVersion()
+}
+
+func (c *auditingClient) AlphaEnabled() bool {
+	return c.inner.AlphaEnabled()
+}
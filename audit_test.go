@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditingClient(t *testing.T) {
+	now := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		httpChecks   httpChecks
+		httpReaction httpReaction
+		call         func(Client) error
+		expected     AuditRecord
+	}{
+		{
+			name:       "provision success",
+			httpChecks: httpChecks{body: successProvisionRequestBody},
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			call: func(c Client) error {
+				r := defaultProvisionRequest()
+				r.OriginatingIdentity = testOriginatingIdentity
+				_, err := c.ProvisionInstance(r)
+				return err
+			},
+			expected: AuditRecord{
+				Operation:           "ProvisionInstance",
+				InstanceID:          testInstanceID,
+				OriginatingIdentity: testOriginatingIdentity,
+				Timestamp:           now,
+			},
+		},
+		{
+			name:       "bind failure",
+			httpChecks: httpChecks{body: defaultBindRequestBody},
+			httpReaction: httpReaction{
+				status: http.StatusInternalServerError,
+				body:   conventionalFailureResponseBody,
+			},
+			call: func(c Client) error {
+				_, err := c.Bind(defaultBindRequest())
+				return err
+			},
+			expected: AuditRecord{
+				Operation:  "Bind",
+				InstanceID: testInstanceID,
+				BindingID:  testBindingID,
+				Timestamp:  now,
+				StatusCode: http.StatusInternalServerError,
+				Error:      testHTTPStatusCodeError(),
+			},
+		},
+		{
+			name: "catalog",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"services":[]}`,
+			},
+			call: func(c Client) error {
+				_, err := c.GetCatalog()
+				return err
+			},
+			expected: AuditRecord{
+				Operation: "GetCatalog",
+				Timestamp: now,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		var records []AuditRecord
+		inner := newTestClient(t, tc.name, Version2_13(), false, tc.httpChecks, tc.httpReaction)
+		auditing := NewAuditingClient(inner, AuditSinkFunc(func(record AuditRecord) {
+			records = append(records, record)
+		}))
+		auditing.(*auditingClient).clock = fakeClock(now)
+
+		err := tc.call(auditing)
+		if e, a := tc.expected.Error, err; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected error; expected %v, got %v", tc.name, e, a)
+		}
+		if e, a := []AuditRecord{tc.expected}, records; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected records;\n\nexpected: %+v\n\ngot:      %+v", tc.name, e, a)
+		}
+	}
+}
+
+func TestAuditingClientGeneratedInstanceID(t *testing.T) {
+	var records []AuditRecord
+	inner := newTestClient(t, "generated instance ID", Version2_13(), false, httpChecks{body: successProvisionRequestBody}, httpReaction{
+		status: http.StatusCreated,
+		body:   successProvisionResponseBody,
+	})
+	inner.GenerateInstanceIDs = true
+	auditing := NewAuditingClient(inner, AuditSinkFunc(func(record AuditRecord) {
+		records = append(records, record)
+	}))
+
+	request := defaultProvisionRequest()
+	request.InstanceID = ""
+	response, err := auditing.ProvisionInstance(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if request.InstanceID != "" {
+		t.Errorf("expected the request to be left unchanged, got instance ID %q", request.InstanceID)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %+v", records)
+	}
+	if e, a := response.InstanceID, records[0].InstanceID; e == "" || e != a {
+		t.Errorf("expected the record to hold the generated instance ID %q, got %q", e, a)
+	}
+}
+
+func TestAuditingClientConfiguration(t *testing.T) {
+	inner := newTestClient(t, "configuration", Version2_13(), true, httpChecks{}, httpReaction{})
+	auditing := NewAuditingClient(inner, AuditSinkFunc(func(record AuditRecord) {
+		t.Errorf("unexpected record %+v", record)
+	}))
+
+	if e, a := inner.BrokerURL(), auditing.BrokerURL(); e != a {
+		t.Errorf("expected URL %q, got %q", e, a)
+	}
+	if e, a := inner.BrokerAPIVersion(), auditing.BrokerAPIVersion(); e != a {
+		t.Errorf("expected API version %v, got %v", e, a)
+	}
+	if !auditing.AlphaEnabled() {
+		t.Error("expected alpha features to be enabled")
+	}
+}
+
+// clientOnly hides the optional interfaces of the client it embeds.
+type clientOnly struct {
+	Client
+}
+
+func TestAuditingClientGetBindingsNotSupported(t *testing.T) {
+	inner := newTestClient(t, "get bindings", Version2_14(), false, httpChecks{}, httpReaction{})
+	auditing := NewAuditingClient(clientOnly{inner}, AuditSinkFunc(func(record AuditRecord) {
+		t.Errorf("unexpected record %+v", record)
+	}))
+
+	lister, ok := auditing.(BindingLister)
+	if !ok {
+		t.Fatal("expected the auditing client to be a BindingLister")
+	}
+	if _, err := lister.GetBindings(testInstanceID); !IsGetBindingsNotSupportedError(err) {
+		t.Errorf("expected a GetBindingsNotSupportedError, got %v", err)
+	}
+}
+
+func TestAuditingClientInstanceExistsNotSupported(t *testing.T) {
+	inner := newTestClient(t, "instance exists", Version2_14(), false, httpChecks{}, httpReaction{})
+	auditing := NewAuditingClient(clientOnly{inner}, AuditSinkFunc(func(record AuditRecord) {
+		t.Errorf("unexpected record %+v", record)
+	}))
+
+	checker, ok := auditing.(InstanceChecker)
+	if !ok {
+		t.Fatal("expected the auditing client to be an InstanceChecker")
+	}
+	if _, err := checker.InstanceExists(context.Background(), testInstanceID); err == nil {
+		t.Error("expected an error for an inner client without InstanceExists")
+	}
+}
@@ -45,7 +45,7 @@ func TestBasicAuth(t *testing.T) {
 			BasicAuthConfig: tc.BasicAuthConfig,
 		}
 		client.doRequestFunc = addBasicAuthCheck(t, tc.name, tc.BasicAuthConfig, client.doRequestFunc)
-		_, _ = client.prepareAndDo(http.MethodGet, client.URL, nil, nil, nil)
+		_, _ = client.prepareAndDo("GetCatalog", http.MethodGet, client.URL, nil, nil, nil)
 	}
 }
 
@@ -71,7 +71,7 @@ func TestBearerAuth(t *testing.T) {
 			BearerConfig: tc.BearerConfig,
 		}
 		client.doRequestFunc = addBearerAuthCheck(t, tc.name, tc.BearerConfig, client.doRequestFunc)
-		_, _ = client.prepareAndDo(http.MethodGet, client.URL, nil, nil, nil)
+		_, _ = client.prepareAndDo("GetCatalog", http.MethodGet, client.URL, nil, nil, nil)
 	}
 }
 
@@ -130,3 +130,48 @@ func parseBearerToken(auth string) (token string, ok bool) {
 	}
 	return auth[len(prefix):], true
 }
+
+func TestCheckRedirect(t *testing.T) {
+	cases := []struct {
+		name            string
+		followRedirects bool
+		target          string
+		expectedErr     bool
+		expectedAuth    bool
+	}{
+		{
+			name:   "same host",
+			target: "https://example.com/elsewhere",
+		},
+		{
+			name:        "cross host refused",
+			target:      "https://relocated.example.com/v2/catalog",
+			expectedErr: true,
+		},
+		{
+			name:            "cross host followed with auth",
+			followRedirects: true,
+			target:          "https://relocated.example.com/v2/catalog",
+			expectedAuth:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		client := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		client.FollowRedirects = tc.followRedirects
+		client.AuthConfig = &AuthConfig{
+			BearerConfig: &BearerConfig{Token: "SuchToken"},
+		}
+
+		original, _ := http.NewRequest(http.MethodGet, "https://example.com/v2/catalog", nil)
+		redirected, _ := http.NewRequest(http.MethodGet, tc.target, nil)
+
+		err := client.checkRedirect(redirected, []*http.Request{original})
+		if e, a := tc.expectedErr, err != nil; e != a {
+			t.Errorf("%v: expected error %v, got %v", tc.name, e, err)
+		}
+		if e, a := tc.expectedAuth, redirected.Header.Get("Authorization") == "Bearer SuchToken"; e != a {
+			t.Errorf("%v: expected auth header %v, got %q", tc.name, e, redirected.Header.Get("Authorization"))
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"sync"
+)
+
+// UnbindResult is the outcome of a single unbind performed by UnbindAll.
+type UnbindResult struct {
+	// Request is the request that was sent.
+	Request *UnbindRequest
+	// Response is the broker's response, if the unbind succeeded.
+	Response *UnbindResponse
+	// Error is the error returned for the request, if any.
+	Error error
+}
+
+// DeprovisionResult is the outcome of a single deprovision performed by
+// DeprovisionAll.
+type DeprovisionResult struct {
+	// Request is the request that was sent.
+	Request *DeprovisionRequest
+	// Response is the broker's response, if the deprovision succeeded.
+	Response *DeprovisionResponse
+	// Error is the error returned for the request, if any.
+	Error error
+}
+
+// UnbindAll unbinds each of the given requests using the given client, with
+// at most concurrency requests in flight at a time.  The returned results are
+// in the same order as the requests.  A binding that is already gone is
+// treated as successfully unbound.  If ctx is cancelled, requests that have
+// not yet been sent are not sent and their results carry the context's
+// error.  Requests without an OriginatingIdentity are sent with the one set
+// on ctx by WithOriginatingIdentity, if any, without being modified.
+func UnbindAll(ctx context.Context, c Binder, reqs []*UnbindRequest, concurrency int) []UnbindResult {
+	results := make([]UnbindResult, len(reqs))
+	runAll(ctx, len(reqs), concurrency, func(i int, err error) {
+		results[i].Request = reqs[i]
+		if err != nil {
+			results[i].Error = err
+			return
+		}
+
+		request := reqs[i]
+		if identity := withContextOriginatingIdentity(ctx, request.OriginatingIdentity); identity != request.OriginatingIdentity {
+			withIdentity := *request
+			withIdentity.OriginatingIdentity = identity
+			request = &withIdentity
+		}
+
+		response, err := c.Unbind(request)
+		if IsGoneError(err) {
+			response, err = &UnbindResponse{}, nil
+		}
+		results[i].Response = response
+		results[i].Error = err
+	})
+	return results
+}
+
+// DeprovisionAll deprovisions each of the given requests using the given
+// client, with at most concurrency requests in flight at a time.  The
+// returned results are in the same order as the requests.  An instance that
+// is already gone is treated as successfully deprovisioned.  If ctx is
+// cancelled, requests that have not yet been sent are not sent and their
+// results carry the context's error.  Requests without an
+// OriginatingIdentity are sent with the one set on ctx by
+// WithOriginatingIdentity, if any, without being modified.
+func DeprovisionAll(ctx context.Context, c Provisioner, reqs []*DeprovisionRequest, concurrency int) []DeprovisionResult {
+	results := make([]DeprovisionResult, len(reqs))
+	runAll(ctx, len(reqs), concurrency, func(i int, err error) {
+		results[i].Request = reqs[i]
+		if err != nil {
+			results[i].Error = err
+			return
+		}
+
+		request := reqs[i]
+		if identity := withContextOriginatingIdentity(ctx, request.OriginatingIdentity); identity != request.OriginatingIdentity {
+			withIdentity := *request
+			withIdentity.OriginatingIdentity = identity
+			request = &withIdentity
+		}
+
+		response, err := c.DeprovisionInstance(request)
+		if IsGoneError(err) {
+			response, err = &DeprovisionResponse{}, nil
+		}
+		results[i].Response = response
+		results[i].Error = err
+	})
+	return results
+}
+
+// runAll calls fn for each index in [0, n) with at most concurrency calls
+// running at a time.  Once ctx is done, fn is called for the remaining
+// indices with the context's error instead of being run normally.
+func runAll(ctx context.Context, n, concurrency int, fn func(i int, err error)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			fn(i, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i, ctx.Err())
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchTestClient is a Client whose Unbind and DeprovisionInstance methods
+// record the peak number of concurrent calls and return errors keyed by
+// instance ID.
+type batchTestClient struct {
+	Client
+
+	errors map[string]error
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (c *batchTestClient) enter(instanceID string) error {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.peak {
+		c.peak = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	return c.errors[instanceID]
+}
+
+func (c *batchTestClient) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
+	if err := c.enter(r.InstanceID); err != nil {
+		return nil, err
+	}
+	return &UnbindResponse{}, nil
+}
+
+func (c *batchTestClient) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionResponse, error) {
+	if err := c.enter(r.InstanceID); err != nil {
+		return nil, err
+	}
+	return &DeprovisionResponse{}, nil
+}
+
+func TestUnbindAll(t *testing.T) {
+	klient := &batchTestClient{
+		errors: map[string]error{
+			"gone":   HTTPStatusCodeError{StatusCode: http.StatusGone},
+			"failed": testHTTPStatusCodeError(),
+		},
+	}
+
+	var reqs []*UnbindRequest
+	for _, id := range []string{"a", "b", "gone", "c", "failed", "d"} {
+		reqs = append(reqs, &UnbindRequest{InstanceID: id, BindingID: testBindingID})
+	}
+
+	results := UnbindAll(context.Background(), klient, reqs, 2)
+
+	if e, a := len(reqs), len(results); e != a {
+		t.Fatalf("expected %v results, got %v", e, a)
+	}
+	for i, result := range results {
+		if result.Request != reqs[i] {
+			t.Errorf("result %v: unexpected request %+v", i, result.Request)
+		}
+		if reqs[i].InstanceID == "failed" {
+			if result.Error == nil {
+				t.Errorf("result %v: expected error", i)
+			}
+			continue
+		}
+		if result.Error != nil || result.Response == nil {
+			t.Errorf("result %v: expected success, got %v", i, result.Error)
+		}
+	}
+	if klient.peak > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %v", klient.peak)
+	}
+}
+
+func TestDeprovisionAll(t *testing.T) {
+	klient := &batchTestClient{
+		errors: map[string]error{
+			"gone": HTTPStatusCodeError{StatusCode: http.StatusGone},
+		},
+	}
+
+	var reqs []*DeprovisionRequest
+	for _, id := range []string{"a", "gone", "b", "c"} {
+		reqs = append(reqs, &DeprovisionRequest{InstanceID: id})
+	}
+
+	results := DeprovisionAll(context.Background(), klient, reqs, 3)
+
+	for i, result := range results {
+		if result.Request != reqs[i] {
+			t.Errorf("result %v: unexpected request %+v", i, result.Request)
+		}
+		if result.Error != nil || result.Response == nil {
+			t.Errorf("result %v: expected success, got %v", i, result.Error)
+		}
+	}
+	if klient.peak > 3 {
+		t.Errorf("expected at most 3 requests in flight, got %v", klient.peak)
+	}
+}
+
+func TestDeprovisionAllCancelled(t *testing.T) {
+	klient := &batchTestClient{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reqs := []*DeprovisionRequest{{InstanceID: "a"}, {InstanceID: "b"}}
+	results := DeprovisionAll(ctx, klient, reqs, 1)
+
+	for i, result := range results {
+		if !errors.Is(result.Error, context.Canceled) {
+			t.Errorf("result %v: expected context cancellation, got %v", i, result.Error)
+		}
+	}
+	if klient.peak != 0 {
+		t.Errorf("expected no requests to be sent, got %v", klient.peak)
+	}
+}
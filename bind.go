@@ -17,6 +17,7 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -40,7 +41,7 @@ type bindSuccessResponseBody struct {
 	VolumeMounts    *[]VolumeMount         `json:"volume_mounts"`
 	Endpoints       *[]Endpoint            `json:"endpoints"`
 	Metadata        *BindingMetadata       `json:"metadata,omitempty"`
-	Operation       *string                `json:"operation"`
+	asyncOperationFields
 }
 
 const (
@@ -61,12 +62,19 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 		return nil, err
 	}
 
+	if c.ValidateParametersAgainstSchemas {
+		if err := c.validateBindParameters(r); err != nil {
+			return nil, err
+		}
+	}
+
+	c.warnDeprecated(bindRequestDeprecations(r))
+
 	fullURL := fmt.Sprintf(bindingURLFmt, c.URL, r.InstanceID, r.BindingID)
 
 	params := map[string]string{}
-	if r.AcceptsIncomplete {
-		params[AcceptsIncomplete] = "true"
-	}
+	acceptsIncomplete := c.bindingAcceptsIncomplete(r.AcceptsIncomplete)
+	setAcceptsIncomplete(params, acceptsIncomplete, r.SendAcceptsIncompleteFalse)
 
 	requestBody := &bindRequestBody{
 		ServiceID:  r.ServiceID,
@@ -75,7 +83,7 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 	}
 
 	if c.APIVersion.AtLeast(Version2_13()) {
-		requestBody.Context = r.Context
+		requestBody.Context = c.requestContext(r.Context)
 	}
 
 	if r.BindResource != nil {
@@ -88,7 +96,7 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 		}
 	}
 
-	response, err := c.prepareAndDo(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("Bind", http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -108,10 +116,11 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 		if !c.EnableAlphaFeatures {
 			userResponse.Endpoints = nil
 		}
+		userResponse.Warnings = responseWarnings(response)
 
 		return userResponse, nil
 	case http.StatusAccepted:
-		if !r.AcceptsIncomplete {
+		if !acceptsIncomplete {
 			return nil, c.handleFailureResponse(response)
 		}
 
@@ -120,11 +129,9 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
-		var opPtr *OperationKey
-		if responseBodyObj.Operation != nil {
-			opStr := *responseBodyObj.Operation
-			op := OperationKey(opStr)
-			opPtr = &op
+		opPtr, err := c.operationKey(responseBodyObj.asyncOperationFields)
+		if err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
 		userResponse := &BindResponse{
@@ -135,12 +142,16 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 			Endpoints:       responseBodyObj.Endpoints,
 			Metadata:        responseBodyObj.Metadata,
 			OperationKey:    opPtr,
+			Warnings:        responseWarnings(response),
 		}
 		if response.StatusCode == http.StatusAccepted {
 			if c.Verbose {
 				klog.Infof("broker %q: received asynchronous response", c.Name)
 			}
 			userResponse.Async = true
+			if c.EnableAlphaFeatures {
+				userResponse.PollDelay = c.pollDelay(response)
+			}
 		}
 
 		return userResponse, nil
@@ -150,21 +161,34 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 }
 
 func validateBindRequest(request *BindRequest) error {
+	var errs []error
+
 	if request.BindingID == "" {
-		return required("bindingID")
+		errs = append(errs, required("bindingID"))
 	}
 
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.ServiceID == "" {
-		return required("serviceID")
+		errs = append(errs, required("serviceID"))
 	}
 
 	if request.PlanID == "" {
-		return required("planID")
+		errs = append(errs, required("planID"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateBindParameters validates the parameters of the given request
+// against the binding create schema of the requested plan, if known.
+func (c *client) validateBindParameters(request *BindRequest) error {
+	schemas := c.planSchemas(request.ServiceID, request.PlanID)
+	if schemas == nil || schemas.ServiceBinding == nil {
+		return nil
 	}
 
-	return nil
+	return ValidateParameters(schemas.ServiceBinding.Create, request.Parameters)
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import "context"
+
+// bindOperation is the kind of operation BindAndWait reports to
+// PollOptions.Metrics.
+const bindOperation = "bind"
+
+// BindAndWait creates a binding using the given client and, if the broker
+// handles the request asynchronously, polls the binding's last operation
+// until it completes.  Since the response to an asynchronous bind does not
+// have to include credentials, or may only include some of them, the binding
+// is then fetched with GetBinding and combined with that response by
+// MergeBindCredentials.  The returned response keeps Async set when the bind
+// was handled asynchronously.
+//
+// If the operation fails, an AsyncOperationFailedError is returned.  If
+// opts.Deadline, the deadline of ctx or the MaximumPollingDuration of
+// opts.Plan passes, or opts.MaxPolls is reached, before the operation
+// completes, a PollingTimeoutError is returned.  Asynchronous bindings are
+// reported to opts.Metrics, if set, as "bind" operations.  If r has no
+// OriginatingIdentity, the one set on ctx by WithOriginatingIdentity is sent.
+func BindAndWait(ctx context.Context, c Client, r *BindRequest, opts PollOptions) (*BindResponse, error) {
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	if identity := withContextOriginatingIdentity(ctx, r.OriginatingIdentity); identity != r.OriginatingIdentity {
+		withIdentity := *r
+		withIdentity.OriginatingIdentity = identity
+		r = &withIdentity
+	}
+
+	started := opts.now()
+	response, err := c.Bind(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Async {
+		return response, nil
+	}
+
+	if err := waitForFirstPoll(ctx, opts, response.PollDelay); err != nil {
+		opts.recordOperation(bindOperation, "", 0, started)
+		return nil, err
+	}
+
+	lastOperationRequest := &BindingLastOperationRequest{
+		InstanceID:          r.InstanceID,
+		BindingID:           r.BindingID,
+		ServiceID:           &r.ServiceID,
+		PlanID:              &r.PlanID,
+		OperationKey:        response.OperationKey,
+		OriginatingIdentity: r.OriginatingIdentity,
+	}
+	_, err = pollUntilDone(ctx, opts, bindOperation, started, func() (*LastOperationResponse, error) {
+		return c.PollBindingLastOperation(lastOperationRequest)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := c.GetBinding(&GetBindingRequest{
+		InstanceID: r.InstanceID,
+		BindingID:  r.BindingID,
+		ServiceID:  r.ServiceID,
+		PlanID:     r.PlanID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return MergeBindCredentials(response, &BindResponse{
+		Credentials:     binding.Credentials,
+		SyslogDrainURL:  binding.SyslogDrainURL,
+		RouteServiceURL: binding.RouteServiceURL,
+		VolumeMounts:    binding.VolumeMounts,
+		Endpoints:       binding.Endpoints,
+		Metadata:        binding.Metadata,
+	}), nil
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// bindAndWaitTestClient is a Client that returns a scripted bind response,
+// a scripted sequence of binding last operation states and a scripted
+// binding.
+type bindAndWaitTestClient struct {
+	Client
+
+	bindResponse *BindResponse
+	states       []LastOperationState
+	binding      *GetBindingResponse
+
+	polls       []*BindingLastOperationRequest
+	getBindings int
+}
+
+func (c *bindAndWaitTestClient) Bind(*BindRequest) (*BindResponse, error) {
+	return c.bindResponse, nil
+}
+
+func (c *bindAndWaitTestClient) PollBindingLastOperation(r *BindingLastOperationRequest) (*LastOperationResponse, error) {
+	c.polls = append(c.polls, r)
+	state := c.states[0]
+	if len(c.states) > 1 {
+		c.states = c.states[1:]
+	}
+	return &LastOperationResponse{State: state, Description: strPtr("test description")}, nil
+}
+
+func (c *bindAndWaitTestClient) GetBinding(*GetBindingRequest) (*GetBindingResponse, error) {
+	c.getBindings++
+	return c.binding, nil
+}
+
+var testPollOptions = PollOptions{Interval: time.Millisecond}
+
+func testAsyncBindResponse() *BindResponse {
+	return &BindResponse{
+		Async:        true,
+		OperationKey: &testOperation,
+	}
+}
+
+func TestBindAndWait(t *testing.T) {
+	credentials := map[string]interface{}{"password": "secret"}
+
+	cases := []struct {
+		name                string
+		bindResponse        *BindResponse
+		states              []LastOperationState
+		expectedPolls       int
+		expectedGetBindings int
+		expectedResponse    *BindResponse
+		expectedErr         error
+	}{
+		{
+			name:             "synchronous bind",
+			bindResponse:     &BindResponse{Credentials: credentials},
+			expectedResponse: &BindResponse{Credentials: credentials},
+		},
+		{
+			name:                "asynchronous bind fetches credentials",
+			bindResponse:        testAsyncBindResponse(),
+			states:              []LastOperationState{StateInProgress, StateInProgress, StateSucceeded},
+			expectedPolls:       3,
+			expectedGetBindings: 1,
+			expectedResponse: &BindResponse{
+				Async:        true,
+				OperationKey: &testOperation,
+				Credentials:  credentials,
+			},
+		},
+		{
+			name: "asynchronous bind with partial credentials",
+			bindResponse: func() *BindResponse {
+				r := testAsyncBindResponse()
+				r.Credentials = map[string]interface{}{"username": "user"}
+				r.VolumeMounts = &[]VolumeMount{{Driver: strPtr("nfs")}}
+				return r
+			}(),
+			states:              []LastOperationState{StateSucceeded},
+			expectedPolls:       1,
+			expectedGetBindings: 1,
+			expectedResponse: &BindResponse{
+				Async:        true,
+				OperationKey: &testOperation,
+				Credentials:  map[string]interface{}{"username": "user", "password": "secret"},
+				VolumeMounts: &[]VolumeMount{{Driver: strPtr("nfs")}},
+			},
+		},
+		{
+			name:          "asynchronous bind fails",
+			bindResponse:  testAsyncBindResponse(),
+			states:        []LastOperationState{StateInProgress, StateFailed},
+			expectedPolls: 2,
+			expectedErr:   AsyncOperationFailedError{Description: strPtr("test description")},
+		},
+	}
+	for _, tc := range cases {
+		klient := &bindAndWaitTestClient{
+			bindResponse: tc.bindResponse,
+			states:       tc.states,
+			binding:      &GetBindingResponse{Credentials: credentials},
+		}
+
+		response, err := BindAndWait(context.Background(), klient, defaultAsyncBindRequest(), testPollOptions)
+
+		doResponseChecks(t, tc.name, response, err, tc.expectedResponse, "", tc.expectedErr)
+		if e, a := tc.expectedPolls, len(klient.polls); e != a {
+			t.Errorf("%v: expected %v polls, got %v", tc.name, e, a)
+		}
+		if e, a := tc.expectedGetBindings, klient.getBindings; e != a {
+			t.Errorf("%v: expected %v GetBinding calls, got %v", tc.name, e, a)
+		}
+		for _, poll := range klient.polls {
+			if poll.OperationKey == nil || *poll.OperationKey != testOperation {
+				t.Errorf("%v: expected operation key %v to be polled, got %v", tc.name, testOperation, poll.OperationKey)
+			}
+		}
+	}
+}
+
+func TestBindAndWaitCancelled(t *testing.T) {
+	klient := &bindAndWaitTestClient{
+		bindResponse: testAsyncBindResponse(),
+		states:       []LastOperationState{StateInProgress},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	response, err := BindAndWait(ctx, klient, defaultAsyncBindRequest(), testPollOptions)
+	if response != nil {
+		t.Errorf("expected no response, got %+v", response)
+	}
+	if !IsPollingTimeoutError(err) {
+		t.Errorf("expected a PollingTimeoutError, got %v", err)
+	}
+	// A short poll interval must not extend the overall deadline.
+	if len(klient.polls) < 2 {
+		t.Errorf("expected several polls before the deadline, got %v", len(klient.polls))
+	}
+}
+
+func TestBindAndWaitDeadline(t *testing.T) {
+	klient := &bindAndWaitTestClient{
+		bindResponse: testAsyncBindResponse(),
+		states:       []LastOperationState{StateInProgress},
+	}
+
+	opts := PollOptions{
+		Interval: time.Hour,
+		Deadline: time.Now().Add(20 * time.Millisecond),
+	}
+
+	start := time.Now()
+	response, err := BindAndWait(context.Background(), klient, defaultBindRequest(), opts)
+	elapsed := time.Since(start)
+
+	if response != nil {
+		t.Errorf("expected no response, got %+v", response)
+	}
+	if elapsed > time.Second {
+		t.Errorf("expected the deadline to interrupt polling, returned after %v", elapsed)
+	}
+	if e, a := 1, len(klient.polls); e != a {
+		t.Errorf("expected %v polls, got %v", e, a)
+	}
+
+	expectedErr := PollingTimeoutError{
+		LastResponse: &LastOperationResponse{State: StateInProgress, Description: strPtr("test description")},
+	}
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Fatalf("unexpected error; expected %v, got %v", expectedErr, err)
+	}
+	if e, a := "timed out waiting for asynchronous operation: last state: in progress; description: test description", err.Error(); e != a {
+		t.Errorf("unexpected error message; expected %q, got %q", e, a)
+	}
+}
+
+func TestBindAndWaitRespectsInitialPollDelay(t *testing.T) {
+	bindResponse := testAsyncBindResponse()
+	bindResponse.PollDelay = durationPtr(time.Hour)
+	klient := &bindAndWaitTestClient{
+		bindResponse: bindResponse,
+		states:       []LastOperationState{StateSucceeded},
+	}
+
+	opts := PollOptions{
+		Interval: time.Millisecond,
+		Deadline: time.Now().Add(20 * time.Millisecond),
+	}
+
+	_, err := BindAndWait(context.Background(), klient, defaultBindRequest(), opts)
+	if e, a := (PollingTimeoutError{}), err; e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := 0, len(klient.polls); e != a {
+		t.Errorf("expected %v polls before the initial delay elapsed, got %v", e, a)
+	}
+}
+
+func TestBindAndWaitBoundedByPlanMaximumPollingDuration(t *testing.T) {
+	maximumPollingDuration := int64(1)
+	klient := &bindAndWaitTestClient{
+		bindResponse: testAsyncBindResponse(),
+		states:       []LastOperationState{StateInProgress},
+	}
+
+	clock := &fakePollClock{now: time.Now()}
+	start := clock.now
+	opts := PollOptions{
+		Interval: 10 * time.Millisecond,
+		Plan:     &Plan{MaximumPollingDuration: &maximumPollingDuration},
+		clock:    clock.Now,
+		newTimer: clock.NewTimer,
+	}
+
+	_, err := BindAndWait(context.Background(), klient, defaultBindRequest(), opts)
+
+	if !IsPollingTimeoutError(err) {
+		t.Fatalf("expected a PollingTimeoutError, got %v", err)
+	}
+	if e, a := time.Second, clock.now.Sub(start); e != a {
+		t.Errorf("expected the flow to be bounded by the plan's maximum polling duration of %v, returned after %v", e, a)
+	}
+	if e, a := 100, len(klient.polls); e != a {
+		t.Errorf("expected %v polls, got %v", e, a)
+	}
+}
+
+func TestBindAndWaitRecordsMetrics(t *testing.T) {
+	client := &bindAndWaitTestClient{
+		bindResponse: func() *BindResponse {
+			r := testAsyncBindResponse()
+			r.Credentials = map[string]interface{}{"password": "secret"}
+			return r
+		}(),
+		states:  []LastOperationState{StateInProgress, StateSucceeded},
+		binding: &GetBindingResponse{},
+	}
+	recorder := &testPollMetricsRecorder{}
+	opts := testPollOptions
+	opts.Metrics = recorder
+
+	if _, err := BindAndWait(context.Background(), client, defaultAsyncBindRequest(), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []recordedOperation{{operation: "bind", state: StateSucceeded, polls: 2}}
+	if e, a := expected, recorder.operations; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %+v, got %+v", e, a)
+	}
+}
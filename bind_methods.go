@@ -0,0 +1,190 @@
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// RedactedValue replaces the secret values of a sanitized response.
+const RedactedValue = "[REDACTED]"
+
+// Sanitized returns a deep copy of the response that is safe to log or
+// record in events: the values of Credentials and of the mount config of
+// VolumeMounts are replaced by RedactedValue, keeping their keys, and
+// SyslogDrainURL and RouteServiceURL, which may embed credentials, are
+// redacted as a whole.  It returns nil for a nil response.
+func (r *BindResponse) Sanitized() *BindResponse {
+	if r == nil {
+		return nil
+	}
+
+	sanitized := *r
+	sanitized.Credentials = redactValues(r.Credentials)
+	sanitized.SyslogDrainURL = redactString(r.SyslogDrainURL)
+	sanitized.RouteServiceURL = redactString(r.RouteServiceURL)
+
+	if r.VolumeMounts != nil {
+		volumeMounts := make([]VolumeMount, len(*r.VolumeMounts))
+		for i, volumeMount := range *r.VolumeMounts {
+			volumeMounts[i] = volumeMount.sanitized()
+		}
+		sanitized.VolumeMounts = &volumeMounts
+	}
+
+	if r.Endpoints != nil {
+		endpoints := make([]Endpoint, len(*r.Endpoints))
+		for i, endpoint := range *r.Endpoints {
+			endpoints[i] = endpoint
+			endpoints[i].Ports = append([]uint16(nil), endpoint.Ports...)
+			if endpoint.Protocol != nil {
+				endpoints[i].Protocol = Ptr(*endpoint.Protocol)
+			}
+		}
+		sanitized.Endpoints = &endpoints
+	}
+
+	if r.Metadata != nil {
+		sanitized.Metadata = Ptr(*r.Metadata)
+	}
+	if r.OperationKey != nil {
+		sanitized.OperationKey = Ptr(*r.OperationKey)
+	}
+	if r.PollDelay != nil {
+		sanitized.PollDelay = Ptr(*r.PollDelay)
+	}
+
+	return &sanitized
+}
+
+func (m VolumeMount) sanitized() VolumeMount {
+	sanitized := VolumeMount{
+		Driver:       copyString(m.Driver),
+		ContainerDir: copyString(m.ContainerDir),
+		Mode:         copyString(m.Mode),
+		DeviceType:   copyString(m.DeviceType),
+	}
+
+	if m.Device != nil {
+		sanitized.Device = &VolumeMountDevice{
+			VolumeID: copyString(m.Device.VolumeID),
+		}
+		if m.Device.MountConfig != nil {
+			mountConfig := redactValues(*m.Device.MountConfig)
+			sanitized.Device.MountConfig = &mountConfig
+		}
+	}
+
+	return sanitized
+}
+
+// redactValues returns a copy of the given map with every value replaced by
+// RedactedValue, or nil for a nil map.
+func redactValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(values))
+	for k := range values {
+		redacted[k] = RedactedValue
+	}
+	return redacted
+}
+
+func redactString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return Ptr(RedactedValue)
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	return Ptr(*s)
+}
+
+// MergeBindCredentials combines the response to an asynchronous bind with
+// the binding fetched once the operation completed, for brokers that split
+// the delivery of credentials between them.  Credentials keys from both are
+// kept, the values of fetched winning for keys present in both; the volume
+// mounts and endpoints of both are united, without duplicates; the other
+// binding fields are taken from fetched when it sets them.  The fields
+// describing the operation itself, such as Async and OperationKey, are those
+// of initial.  Neither response is modified, and if one of them is nil, the
+// other is returned.
+func MergeBindCredentials(initial, fetched *BindResponse) *BindResponse {
+	if initial == nil {
+		return fetched
+	}
+	if fetched == nil {
+		return initial
+	}
+
+	merged := *initial
+
+	if initial.Credentials != nil || fetched.Credentials != nil {
+		merged.Credentials = make(map[string]interface{}, len(initial.Credentials)+len(fetched.Credentials))
+		for k, v := range initial.Credentials {
+			merged.Credentials[k] = v
+		}
+		for k, v := range fetched.Credentials {
+			merged.Credentials[k] = v
+		}
+	}
+
+	if fetched.SyslogDrainURL != nil {
+		merged.SyslogDrainURL = fetched.SyslogDrainURL
+	}
+	if fetched.RouteServiceURL != nil {
+		merged.RouteServiceURL = fetched.RouteServiceURL
+	}
+	if fetched.Metadata != nil {
+		merged.Metadata = fetched.Metadata
+	}
+
+	merged.VolumeMounts = unionOf(initial.VolumeMounts, fetched.VolumeMounts)
+	merged.Endpoints = unionOf(initial.Endpoints, fetched.Endpoints)
+
+	return &merged
+}
+
+// unionOf returns the elements of a followed by those of b that are not
+// deeply equal to an element already kept, or nil if both are nil.
+func unionOf[T any](a, b *[]T) *[]T {
+	if a == nil && b == nil {
+		return nil
+	}
+
+	var union []T
+	for _, list := range []*[]T{a, b} {
+		if list == nil {
+			continue
+		}
+	elements:
+		for _, element := range *list {
+			for _, kept := range union {
+				if reflect.DeepEqual(kept, element) {
+					continue elements
+				}
+			}
+			union = append(union, element)
+		}
+	}
+	if union == nil {
+		union = []T{}
+	}
+	return &union
+}
+
+// MarshalJSON encodes nil Ports as an empty array, which the spec requires,
+// rather than as null.
+func (e Endpoint) MarshalJSON() ([]byte, error) {
+	type endpoint Endpoint
+	r := endpoint(e)
+	if r.Ports == nil {
+		r.Ports = []uint16{}
+	}
+	return json.Marshal(r)
+}
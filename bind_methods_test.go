@@ -0,0 +1,161 @@
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindResponseSanitized(t *testing.T) {
+	secrets := []string{"s3cr3t-password", "s3cr3t-user", "drain-token", "route-token", "nfs-password"}
+	newResponse := func() *BindResponse {
+		return &BindResponse{
+			Credentials: map[string]interface{}{
+				"username": "s3cr3t-user",
+				"password": "s3cr3t-password",
+				"nested":   map[string]interface{}{"password": "s3cr3t-password"},
+			},
+			SyslogDrainURL:  strPtr("syslog://drain-token@example.com"),
+			RouteServiceURL: strPtr("https://route-token@example.com"),
+			VolumeMounts: &[]VolumeMount{{
+				Driver: strPtr("nfs"),
+				Device: &VolumeMountDevice{
+					VolumeID:    strPtr("volume"),
+					MountConfig: &map[string]interface{}{"password": "nfs-password"},
+				},
+			}},
+			Endpoints:    &[]Endpoint{{Host: "example.com", Ports: []uint16{443}}},
+			OperationKey: &testOperation,
+		}
+	}
+
+	original := newResponse()
+	sanitized := original.Sanitized()
+
+	if !reflect.DeepEqual(newResponse(), original) {
+		t.Errorf("expected original response to be unchanged, got %+v", original)
+	}
+
+	sanitizedJSON, err := json.Marshal(sanitized)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, secret := range secrets {
+		if strings.Contains(string(sanitizedJSON), secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, sanitizedJSON)
+		}
+	}
+
+	expectedCredentials := map[string]interface{}{
+		"username": RedactedValue,
+		"password": RedactedValue,
+		"nested":   RedactedValue,
+	}
+	if e, a := expectedCredentials, sanitized.Credentials; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected credentials; expected %v, got %v", e, a)
+	}
+	if e, a := "volume", *(*sanitized.VolumeMounts)[0].Device.VolumeID; e != a {
+		t.Errorf("unexpected volume ID; expected %q, got %q", e, a)
+	}
+	if e, a := original.Endpoints, sanitized.Endpoints; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected endpoints; expected %v, got %v", e, a)
+	}
+
+	(*sanitized.Endpoints)[0].Ports[0] = 80
+	if e, a := uint16(443), (*original.Endpoints)[0].Ports[0]; e != a {
+		t.Errorf("expected a deep copy; original port changed to %v", a)
+	}
+
+	if (*BindResponse)(nil).Sanitized() != nil {
+		t.Error("expected nil for a nil response")
+	}
+}
+
+func TestMergeBindCredentials(t *testing.T) {
+	endpoint := func(host string) Endpoint {
+		return Endpoint{Host: host, Ports: []uint16{443}}
+	}
+
+	cases := []struct {
+		name     string
+		initial  *BindResponse
+		fetched  *BindResponse
+		expected *BindResponse
+	}{
+		{
+			name: "disjoint credentials",
+			initial: &BindResponse{
+				Async:        true,
+				OperationKey: &testOperation,
+				Credentials:  map[string]interface{}{"username": "user"},
+			},
+			fetched: &BindResponse{
+				Credentials: map[string]interface{}{"password": "secret"},
+			},
+			expected: &BindResponse{
+				Async:        true,
+				OperationKey: &testOperation,
+				Credentials:  map[string]interface{}{"username": "user", "password": "secret"},
+			},
+		},
+		{
+			name: "overlapping credentials",
+			initial: &BindResponse{
+				Credentials:    map[string]interface{}{"username": "user", "password": "temporary"},
+				SyslogDrainURL: strPtr("syslog://initial"),
+			},
+			fetched: &BindResponse{
+				Credentials: map[string]interface{}{"password": "secret", "uri": "db://example.com"},
+			},
+			expected: &BindResponse{
+				Credentials:    map[string]interface{}{"username": "user", "password": "secret", "uri": "db://example.com"},
+				SyslogDrainURL: strPtr("syslog://initial"),
+			},
+		},
+		{
+			name: "united endpoints and volume mounts",
+			initial: &BindResponse{
+				Endpoints: &[]Endpoint{endpoint("a.example.com"), endpoint("b.example.com")},
+			},
+			fetched: &BindResponse{
+				Endpoints:       &[]Endpoint{endpoint("b.example.com"), endpoint("c.example.com")},
+				VolumeMounts:    &[]VolumeMount{{Driver: strPtr("nfs")}},
+				RouteServiceURL: strPtr("https://route.example.com"),
+			},
+			expected: &BindResponse{
+				Endpoints:       &[]Endpoint{endpoint("a.example.com"), endpoint("b.example.com"), endpoint("c.example.com")},
+				VolumeMounts:    &[]VolumeMount{{Driver: strPtr("nfs")}},
+				RouteServiceURL: strPtr("https://route.example.com"),
+			},
+		},
+		{
+			name:     "no fetched binding",
+			initial:  &BindResponse{Credentials: map[string]interface{}{"username": "user"}},
+			expected: &BindResponse{Credentials: map[string]interface{}{"username": "user"}},
+		},
+		{
+			name:     "no initial response",
+			fetched:  &BindResponse{Credentials: map[string]interface{}{"username": "user"}},
+			expected: &BindResponse{Credentials: map[string]interface{}{"username": "user"}},
+		},
+	}
+
+	for _, tc := range cases {
+		var initialCredentials map[string]interface{}
+		if tc.initial != nil && tc.initial.Credentials != nil {
+			initialCredentials = map[string]interface{}{}
+			for k, v := range tc.initial.Credentials {
+				initialCredentials[k] = v
+			}
+		}
+
+		merged := MergeBindCredentials(tc.initial, tc.fetched)
+		if e, a := tc.expected, merged; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %+v, got %+v", tc.name, e, a)
+		}
+		if tc.initial != nil && !reflect.DeepEqual(initialCredentials, tc.initial.Credentials) {
+			t.Errorf("%v: expected initial credentials to be left unmodified, got %v", tc.name, tc.initial.Credentials)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 )
 
 const testBindingID = "test-binding-id"
@@ -249,6 +250,54 @@ func TestBind(t *testing.T) {
 			},
 			expectedResponse: successBindResponseWithMetadata(),
 		},
+		{
+			name:        "success - asynchronous with retry delay header",
+			version:     LatestAPIVersion(),
+			enableAlpha: true,
+			request:     defaultAsyncBindRequest(),
+			httpChecks: httpChecks{
+				params: map[string]string{
+					AcceptsIncomplete: "true",
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusAccepted,
+				body:   successAsyncBindResponseBody,
+				header: map[string][]string{PollingDelayHeader: {"30"}},
+			},
+			expectedResponse: func() *BindResponse {
+				r := successBindResponseAsync()
+				r.PollDelay = durationPtr(30 * time.Second)
+				return r
+			}(),
+		},
+		{
+			name:    "success - asynchronous with retry delay header and alpha features disabled",
+			version: Version2_14(),
+			request: defaultAsyncBindRequest(),
+			httpChecks: httpChecks{
+				params: map[string]string{
+					AcceptsIncomplete: "true",
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusAccepted,
+				body:   successAsyncBindResponseBody,
+				header: map[string][]string{PollingDelayHeader: {"30"}},
+			},
+			expectedResponse: successBindResponseAsync(),
+		},
+		{
+			name:        "success - synchronous ignores retry delay header",
+			version:     LatestAPIVersion(),
+			enableAlpha: true,
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successBindResponseBody,
+				header: map[string][]string{PollingDelayHeader: {"30"}},
+			},
+			expectedResponse: successBindResponse(),
+		},
 		{
 			name:    "success - asynchronous",
 			version: Version2_14(),
@@ -477,3 +526,99 @@ func TestValidateBindRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestBindValidateParametersAgainstSchemas(t *testing.T) {
+	catalog := &CatalogResponse{
+		Services: []Service{
+			{
+				ID: testServiceID,
+				Plans: []Plan{
+					{
+						ID: testPlanID,
+						Schemas: &Schemas{
+							ServiceBinding: &ServiceBindingSchema{
+								Create: testInputParametersSchema(t, testParametersSchema),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name               string
+		validate           bool
+		catalog            *CatalogResponse
+		parameters         map[string]interface{}
+		expectedErrMessage string
+	}{
+		{
+			name:               "rejected by schema",
+			validate:           true,
+			catalog:            catalog,
+			parameters:         map[string]interface{}{"size": 100},
+			expectedErrMessage: "parameters.size: 100 is greater than the maximum of 10",
+		},
+		{
+			name:       "accepted by schema",
+			validate:   true,
+			catalog:    catalog,
+			parameters: map[string]interface{}{"size": 1},
+		},
+		{
+			name:       "validation disabled",
+			catalog:    catalog,
+			parameters: map[string]interface{}{"size": 100},
+		},
+		{
+			name:       "catalog not fetched",
+			validate:   true,
+			parameters: map[string]interface{}{"size": 100},
+		},
+	}
+
+	for _, tc := range cases {
+		request := defaultBindRequest()
+		request.Parameters = tc.parameters
+
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		klient.ValidateParametersAgainstSchemas = tc.validate
+		klient.cacheSchemas(tc.catalog)
+		sent := false
+		klient.doRequestFunc = func(*http.Request) (*http.Response, error) {
+			sent = true
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       closer(successBindResponseBody),
+			}, nil
+		}
+
+		_, err := klient.Bind(request)
+		if tc.expectedErrMessage != "" {
+			if err == nil || err.Error() != tc.expectedErrMessage {
+				t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+			}
+			if sent {
+				t.Errorf("%v: expected request not to be sent", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+	}
+}
+
+func TestBindAsyncUnsupportedVersionNotSent(t *testing.T) {
+	klient := newTestClient(t, "async bind with 2.13", Version2_13(), false, httpChecks{}, httpReaction{})
+	klient.doRequestFunc = func(*http.Request) (*http.Response, error) {
+		t.Error("unexpected request sent to the broker")
+		return nil, errWalkingGhost
+	}
+
+	_, err := klient.Bind(defaultAsyncBindRequest())
+	if !IsAsyncBindingOperationsNotAllowedError(err) {
+		t.Errorf("expected an AsyncBindingOperationsNotAllowedError, got %v", err)
+	}
+}
@@ -0,0 +1,403 @@
+package v2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Keys of plan metadata that some legacy brokers use instead of the Free and
+// Bindable fields.
+const (
+	planMetadataFreeKey     = "free"
+	planMetadataBindableKey = "bindable"
+)
+
+// planMetadataCostsKey is the key of plan metadata under which Cloud Foundry
+// catalogs conventionally list the costs of a plan.
+const planMetadataCostsKey = "costs"
+
+// IsFree returns whether the plan is available without charge.  An unset
+// Free field defaults to true.
+func (p Plan) IsFree() bool {
+	return p.ResolveFree(false)
+}
+
+// ResolveFree is like IsFree, but if metadataFallback is set and the Free
+// field is unset, it first consults the "free" key of the plan's metadata,
+// which some legacy brokers use instead.  The key is honored if it holds a
+// boolean or the string "true" or "false".
+func (p Plan) ResolveFree(metadataFallback bool) bool {
+	if p.Free != nil {
+		return *p.Free
+	}
+	if metadataFallback {
+		if free, ok := metadataBool(p.Metadata, planMetadataFreeKey); ok {
+			return free
+		}
+	}
+	return true
+}
+
+// IsPlanBindable returns whether the given plan of the service is bindable.
+// The plan's Bindable field, if set, overrides the service's Bindable field.
+func (s Service) IsPlanBindable(p Plan) bool {
+	return s.ResolvePlanBindable(p, false)
+}
+
+// ResolvePlanBindable is like IsPlanBindable, but if metadataFallback is set
+// and the plan's Bindable field is unset, it first consults the "bindable"
+// key of the plan's metadata, which some legacy brokers use instead.  The
+// key is honored if it holds a boolean or the string "true" or "false".
+func (s Service) ResolvePlanBindable(p Plan, metadataFallback bool) bool {
+	if p.Bindable != nil {
+		return *p.Bindable
+	}
+	if metadataFallback {
+		if bindable, ok := metadataBool(p.Metadata, planMetadataBindableKey); ok {
+			return bindable
+		}
+	}
+	return s.Bindable
+}
+
+// BindablePlans returns the plans of the service that are bindable, as
+// reported by IsPlanBindable, in catalog order.
+func (s Service) BindablePlans() []Plan {
+	var plans []Plan
+	for _, plan := range s.Plans {
+		if s.IsPlanBindable(plan) {
+			plans = append(plans, plan)
+		}
+	}
+	return plans
+}
+
+// IsBindingRotatable returns whether bindings of the plan support binding
+// rotation.  An unset BindingRotatable field defaults to false.
+func (p Plan) IsBindingRotatable() bool {
+	return p.BindingRotatable != nil && *p.BindingRotatable
+}
+
+// Costs returns the costs listed under the "costs" key of the plan's
+// metadata, following the Cloud Foundry convention.  It returns an empty
+// slice if the plan lists no costs, and an error if the costs are not a list
+// of objects each holding a non-empty amount and a unit.
+func (p Plan) Costs() ([]PlanCost, error) {
+	raw, ok := p.Metadata[planMetadataCostsKey]
+	if !ok || raw == nil {
+		return []PlanCost{}, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("plan %q: malformed costs: %v", p.ID, err)
+	}
+
+	costs := []PlanCost{}
+	if err := json.Unmarshal(data, &costs); err != nil {
+		return nil, fmt.Errorf("plan %q: malformed costs: %v", p.ID, err)
+	}
+
+	for i, cost := range costs {
+		if len(cost.Amount) == 0 {
+			return nil, fmt.Errorf("plan %q: malformed costs: cost %d has no amount", p.ID, i)
+		}
+		if cost.Unit == "" {
+			return nil, fmt.Errorf("plan %q: malformed costs: cost %d has no unit", p.ID, i)
+		}
+	}
+
+	return costs, nil
+}
+
+// metadataBool returns the boolean held by the given metadata key, and
+// whether the key holds a boolean at all.
+func metadataBool(metadata map[string]interface{}, key string) (bool, bool) {
+	switch v := metadata[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		if v != "true" && v != "false" {
+			return false, false
+		}
+		return v == "true", true
+	default:
+		return false, false
+	}
+}
+
+// ResolvePlanUpdatable returns whether instances of the given plan of the
+// service may be updated to a different plan.  The plan's PlanUpdateable
+// field, if set, overrides the service's PlanUpdatable field, which defaults
+// to false.
+func (s Service) ResolvePlanUpdatable(p Plan) bool {
+	if p.PlanUpdateable != nil {
+		return *p.PlanUpdateable
+	}
+	return s.PlanUpdatable != nil && *s.PlanUpdatable
+}
+
+// FindService returns the service with the given ID in the catalog, or nil
+// if there is none.
+func (c CatalogResponse) FindService(serviceID string) *Service {
+	for i := range c.Services {
+		if c.Services[i].ID == serviceID {
+			return &c.Services[i]
+		}
+	}
+	return nil
+}
+
+// FindPlan returns the plan with the given ID of the service, or nil if there
+// is none.
+func (s Service) FindPlan(planID string) *Plan {
+	for i := range s.Plans {
+		if s.Plans[i].ID == planID {
+			return &s.Plans[i]
+		}
+	}
+	return nil
+}
+
+// DashboardClientConfig returns the dashboard OAuth client the platform
+// should register for the service, or nil if the service declares none.  It
+// returns an error naming the missing fields if the dashboard client does
+// not hold all of its ID, secret and redirect URI.
+func (s Service) DashboardClientConfig() (*DashboardClient, error) {
+	if s.DashboardClient == nil {
+		return nil, nil
+	}
+
+	var errs []error
+	if s.DashboardClient.ID == "" {
+		errs = append(errs, required("dashboard client id"))
+	}
+	if s.DashboardClient.Secret == "" {
+		errs = append(errs, required("dashboard client secret"))
+	}
+	if s.DashboardClient.RedirectURI == "" {
+		errs = append(errs, required("dashboard client redirect_uri"))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("service %q: %w", s.ID, errors.Join(errs...))
+	}
+
+	client := *s.DashboardClient
+	return &client, nil
+}
+
+// RequiresPermission returns whether the service requires the given
+// permission, such as RequiresSyslogDrain.
+func (s Service) RequiresPermission(p string) bool {
+	for _, requirement := range s.Requires {
+		if requirement == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns a human-readable listing of the services in the catalog and
+// their plans, aligned in columns and suitable for printing by a CLI.
+func (c CatalogResponse) Summary() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	for i, service := range c.Services {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)\n", service.Name, service.ID)
+		fmt.Fprintln(w, "  PLAN\tID\tFREE\tBINDABLE")
+		for _, plan := range service.Plans {
+			fmt.Fprintf(w, "  %s\t%s\t%t\t%t\n", plan.Name, plan.ID, plan.IsFree(), service.IsPlanBindable(plan))
+		}
+	}
+
+	w.Flush()
+	return b.String()
+}
+
+// Hash returns a deterministic hash of the catalog content, suitable for
+// detecting catalog changes between fetches.  Services and plans are sorted
+// by ID and the catalog is encoded as JSON with sorted map keys, so the hash
+// depends neither on the order in which the broker lists them nor on the
+// order of keys in metadata or schemas.  Hash returns an empty string if the
+// catalog holds values that cannot be encoded as JSON, which never happens
+// for a catalog decoded from a broker response.
+func (c CatalogResponse) Hash() string {
+	services := make([]Service, len(c.Services))
+	copy(services, c.Services)
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+
+	for i := range services {
+		plans := make([]Plan, len(services[i].Plans))
+		copy(plans, services[i].Plans)
+		sort.Slice(plans, func(i, j int) bool { return plans[i].ID < plans[j].ID })
+		services[i].Plans = plans
+	}
+	c.Services = services
+
+	canonical, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateForVersion returns an error listing the fields set in the catalog
+// that are not permitted for the given API version, or without alpha
+// features when alpha is false, following the requirements documented on
+// the fields of Service and Plan.  It lets brokers and platforms detect
+// catalogs that do not match the version they are served with.
+func (c CatalogResponse) ValidateForVersion(v APIVersion, alpha bool) error {
+	var errs []error
+
+	for _, service := range c.Services {
+		if !alpha {
+			if service.InstancesRetrievable {
+				errs = append(errs, fmt.Errorf("service %q: instances_retrievable requires alpha features", service.ID))
+			}
+			if service.BindingsRetrievable {
+				errs = append(errs, fmt.Errorf("service %q: bindings_retrievable requires alpha features", service.ID))
+			}
+		}
+
+		for _, plan := range service.Plans {
+			if plan.Schemas != nil && v.IsLessThan(Version2_13()) {
+				errs = append(errs, fmt.Errorf("service %q, plan %q: schemas requires API version >= 2.13, got %s", service.ID, plan.ID, v))
+			}
+			if !alpha {
+				if plan.PlanUpdateable != nil {
+					errs = append(errs, fmt.Errorf("service %q, plan %q: plan_updateable requires alpha features", service.ID, plan.ID))
+				}
+				if plan.MaximumPollingDuration != nil {
+					errs = append(errs, fmt.Errorf("service %q, plan %q: maximum_polling_duration requires alpha features", service.ID, plan.ID))
+				}
+				if plan.MaintenanceInfo != nil {
+					errs = append(errs, fmt.Errorf("service %q, plan %q: maintenance_info requires alpha features", service.ID, plan.ID))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ServicesByTag returns the services of the catalog carrying the given tag,
+// in catalog order.  Tags are compared case-sensitively.
+func (c CatalogResponse) ServicesByTag(tag string) []Service {
+	var services []Service
+	for _, service := range c.Services {
+		if containsString(service.Tags, tag) {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// containsString returns whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
+
+// CatalogIndex indexes the services of a catalog by ID, name and tag, to
+// look them up without scanning large catalogs repeatedly.  It is built by
+// BuildCatalogIndex and is not updated when the catalog changes.
+type CatalogIndex struct {
+	services []Service
+	byID     map[string]int
+	byName   map[string]int
+	byTag    map[string][]int
+}
+
+// BuildCatalogIndex returns an index of the services of the catalog.  If
+// several services share an ID or a name, the first one in catalog order is
+// indexed, as FindService would return.
+func BuildCatalogIndex(c CatalogResponse) *CatalogIndex {
+	index := &CatalogIndex{
+		services: c.Services,
+		byID:     make(map[string]int, len(c.Services)),
+		byName:   make(map[string]int, len(c.Services)),
+		byTag:    make(map[string][]int),
+	}
+
+	for i, service := range c.Services {
+		if _, ok := index.byID[service.ID]; !ok {
+			index.byID[service.ID] = i
+		}
+		if _, ok := index.byName[service.Name]; !ok {
+			index.byName[service.Name] = i
+		}
+		for j, tag := range service.Tags {
+			if !containsString(service.Tags[:j], tag) {
+				index.byTag[tag] = append(index.byTag[tag], i)
+			}
+		}
+	}
+
+	return index
+}
+
+// FindService returns the service with the given ID, or nil if there is
+// none.
+func (x *CatalogIndex) FindService(serviceID string) *Service {
+	if i, ok := x.byID[serviceID]; ok {
+		return &x.services[i]
+	}
+	return nil
+}
+
+// FindServiceByName returns the service with the given name, or nil if there
+// is none.
+func (x *CatalogIndex) FindServiceByName(name string) *Service {
+	if i, ok := x.byName[name]; ok {
+		return &x.services[i]
+	}
+	return nil
+}
+
+// ServicesByTag returns the services carrying the given tag, in catalog
+// order.  Tags are compared case-sensitively.
+func (x *CatalogIndex) ServicesByTag(tag string) []Service {
+	var services []Service
+	for _, i := range x.byTag[tag] {
+		services = append(services, x.services[i])
+	}
+	return services
+}
+
+// MarshalJSON encodes nil Services as an empty array, which the spec
+// requires, rather than as null.
+func (c CatalogResponse) MarshalJSON() ([]byte, error) {
+	type catalogResponse CatalogResponse
+	r := catalogResponse(c)
+	if r.Services == nil {
+		r.Services = []Service{}
+	}
+	return json.Marshal(r)
+}
+
+// MarshalJSON encodes nil Plans as an empty array, which the spec requires,
+// rather than as null.
+func (s Service) MarshalJSON() ([]byte, error) {
+	type service Service
+	r := service(s)
+	if r.Plans == nil {
+		r.Plans = []Plan{}
+	}
+	return json.Marshal(r)
+}
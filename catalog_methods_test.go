@@ -0,0 +1,568 @@
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func falsePtr() *bool {
+	b := false
+	return &b
+}
+
+func TestPlanIsFree(t *testing.T) {
+	cases := []struct {
+		name     string
+		free     *bool
+		expected bool
+	}{
+		{name: "unset", expected: true},
+		{name: "true", free: truePtr(), expected: true},
+		{name: "false", free: falsePtr(), expected: false},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, (Plan{Free: tc.free}).IsFree(); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestServiceIsPlanBindable(t *testing.T) {
+	cases := []struct {
+		name            string
+		serviceBindable bool
+		planBindable    *bool
+		expected        bool
+	}{
+		{name: "service bindable, plan unset", serviceBindable: true, expected: true},
+		{name: "service not bindable, plan unset", serviceBindable: false, expected: false},
+		{name: "plan overrides to false", serviceBindable: true, planBindable: falsePtr(), expected: false},
+		{name: "plan overrides to true", serviceBindable: false, planBindable: truePtr(), expected: true},
+	}
+	for _, tc := range cases {
+		service := Service{Bindable: tc.serviceBindable}
+		if e, a := tc.expected, service.IsPlanBindable(Plan{Bindable: tc.planBindable}); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestServiceBindablePlans(t *testing.T) {
+	plans := []Plan{
+		{ID: "default"},
+		{ID: "bindable", Bindable: truePtr()},
+		{ID: "not-bindable", Bindable: falsePtr()},
+	}
+
+	cases := []struct {
+		name            string
+		serviceBindable bool
+		expected        []string
+	}{
+		{name: "bindable service", serviceBindable: true, expected: []string{"default", "bindable"}},
+		{name: "non-bindable service", serviceBindable: false, expected: []string{"bindable"}},
+	}
+	for _, tc := range cases {
+		service := Service{Bindable: tc.serviceBindable, Plans: plans}
+		var actual []string
+		for _, plan := range service.BindablePlans() {
+			actual = append(actual, plan.ID)
+		}
+		if !reflect.DeepEqual(tc.expected, actual) {
+			t.Errorf("%v: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+
+	if plans := (Service{Bindable: false, Plans: plans[:1]}).BindablePlans(); plans != nil {
+		t.Errorf("expected no bindable plans, got %v", plans)
+	}
+}
+
+func TestPlanResolveFree(t *testing.T) {
+	cases := []struct {
+		name             string
+		free             *bool
+		metadata         map[string]interface{}
+		metadataFallback bool
+		expected         bool
+	}{
+		{name: "metadata ignored without fallback", metadata: map[string]interface{}{"free": false}, expected: true},
+		{name: "metadata bool", metadata: map[string]interface{}{"free": false}, metadataFallback: true, expected: false},
+		{name: "metadata string", metadata: map[string]interface{}{"free": "false"}, metadataFallback: true, expected: false},
+		{name: "invalid metadata falls back to default", metadata: map[string]interface{}{"free": "no"}, metadataFallback: true, expected: true},
+		{name: "no metadata falls back to default", metadataFallback: true, expected: true},
+		{name: "field takes precedence over metadata", free: truePtr(), metadata: map[string]interface{}{"free": false}, metadataFallback: true, expected: true},
+	}
+	for _, tc := range cases {
+		plan := Plan{Free: tc.free, Metadata: tc.metadata}
+		if e, a := tc.expected, plan.ResolveFree(tc.metadataFallback); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestServiceResolvePlanBindable(t *testing.T) {
+	cases := []struct {
+		name             string
+		serviceBindable  bool
+		planBindable     *bool
+		metadata         map[string]interface{}
+		metadataFallback bool
+		expected         bool
+	}{
+		{name: "metadata ignored without fallback", serviceBindable: true, metadata: map[string]interface{}{"bindable": false}, expected: true},
+		{name: "metadata overrides service", serviceBindable: true, metadata: map[string]interface{}{"bindable": false}, metadataFallback: true, expected: false},
+		{name: "metadata string", serviceBindable: false, metadata: map[string]interface{}{"bindable": "true"}, metadataFallback: true, expected: true},
+		{name: "invalid metadata falls back to service", serviceBindable: true, metadata: map[string]interface{}{"bindable": float64(0)}, metadataFallback: true, expected: true},
+		{name: "field takes precedence over metadata", serviceBindable: false, planBindable: truePtr(), metadata: map[string]interface{}{"bindable": false}, metadataFallback: true, expected: true},
+	}
+	for _, tc := range cases {
+		service := Service{Bindable: tc.serviceBindable}
+		plan := Plan{Bindable: tc.planBindable, Metadata: tc.metadata}
+		if e, a := tc.expected, service.ResolvePlanBindable(plan, tc.metadataFallback); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestServiceRequiresPermission(t *testing.T) {
+	service := Service{Requires: []string{RequiresSyslogDrain, RequiresVolumeMount}}
+
+	cases := []struct {
+		permission string
+		expected   bool
+	}{
+		{permission: RequiresSyslogDrain, expected: true},
+		{permission: RequiresVolumeMount, expected: true},
+		{permission: RequiresRouteForwarding, expected: false},
+		{permission: "", expected: false},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, service.RequiresPermission(tc.permission); e != a {
+			t.Errorf("%q: expected %v, got %v", tc.permission, e, a)
+		}
+	}
+}
+
+func TestServiceDashboardClientConfig(t *testing.T) {
+	complete := &DashboardClient{
+		ID:          "client-id",
+		Secret:      "client-secret",
+		RedirectURI: "https://dashboard.example.com",
+	}
+
+	cases := []struct {
+		name               string
+		client             *DashboardClient
+		expected           *DashboardClient
+		expectedErrMessage string
+	}{
+		{
+			name: "no dashboard client",
+		},
+		{
+			name:     "complete dashboard client",
+			client:   complete,
+			expected: complete,
+		},
+		{
+			name: "missing secret",
+			client: &DashboardClient{
+				ID:          "client-id",
+				RedirectURI: "https://dashboard.example.com",
+			},
+			expectedErrMessage: "service \"service-id\": dashboard client secret is required",
+		},
+		{
+			name:               "empty dashboard client",
+			client:             &DashboardClient{},
+			expectedErrMessage: "service \"service-id\": dashboard client id is required\ndashboard client secret is required\ndashboard client redirect_uri is required",
+		},
+	}
+	for _, tc := range cases {
+		service := Service{ID: "service-id", DashboardClient: tc.client}
+		client, err := service.DashboardClientConfig()
+		if tc.expectedErrMessage != "" {
+			if err == nil || err.Error() != tc.expectedErrMessage {
+				t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := tc.expected, client; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %+v, got %+v", tc.name, e, a)
+		}
+		if client != nil && client == tc.client {
+			t.Errorf("%v: expected a copy of the dashboard client", tc.name)
+		}
+	}
+}
+
+func TestCatalogResponseSummary(t *testing.T) {
+	catalog := CatalogResponse{
+		Services: []Service{
+			{
+				ID:       "service-1",
+				Name:     "db",
+				Bindable: true,
+				Plans: []Plan{
+					{ID: "plan-1", Name: "small"},
+					{ID: "plan-10", Name: "large", Free: falsePtr(), Bindable: falsePtr()},
+				},
+			},
+			{
+				ID:   "service-2",
+				Name: "queue",
+				Plans: []Plan{
+					{ID: "plan-2", Name: "default"},
+				},
+			},
+		},
+	}
+
+	expected := `db (service-1)
+  PLAN   ID       FREE   BINDABLE
+  small  plan-1   true   true
+  large  plan-10  false  false
+
+queue (service-2)
+  PLAN     ID      FREE  BINDABLE
+  default  plan-2  true  false
+`
+	if e, a := expected, catalog.Summary(); e != a {
+		t.Errorf("unexpected summary:\n\nexpected:\n%s\ngot:\n%s", e, a)
+	}
+}
+
+func TestCatalogResponseHash(t *testing.T) {
+	const catalogBytes = `{
+  "services": [{
+    "name": "db", "id": "service-1", "description": "database", "bindable": true,
+    "metadata": {"a": "b", "c": {"d": "e", "f": "g"}},
+    "plans": [
+      {"name": "small", "id": "plan-1", "description": "small"},
+      {"name": "large", "id": "plan-2", "description": "large", "metadata": {"x": 1, "y": 2}}
+    ]
+  }, {
+    "name": "queue", "id": "service-2", "description": "queue", "bindable": false,
+    "plans": [{"name": "default", "id": "plan-3", "description": "default"}]
+  }]
+}`
+	const reorderedCatalogBytes = `{
+  "services": [{
+    "plans": [{"description": "default", "id": "plan-3", "name": "default"}],
+    "bindable": false, "description": "queue", "id": "service-2", "name": "queue"
+  }, {
+    "plans": [
+      {"metadata": {"y": 2, "x": 1}, "description": "large", "id": "plan-2", "name": "large"},
+      {"description": "small", "id": "plan-1", "name": "small"}
+    ],
+    "metadata": {"c": {"f": "g", "d": "e"}, "a": "b"},
+    "bindable": true, "description": "database", "id": "service-1", "name": "db"
+  }]
+}`
+
+	decode := func(s string) CatalogResponse {
+		var catalog CatalogResponse
+		if err := json.Unmarshal([]byte(s), &catalog); err != nil {
+			t.Fatalf("unexpected error decoding catalog: %v", err)
+		}
+		return catalog
+	}
+
+	catalog := decode(catalogBytes)
+	reordered := decode(reorderedCatalogBytes)
+
+	hash := catalog.Hash()
+	if hash == "" {
+		t.Fatal("expected a non-empty hash")
+	}
+	if e, a := hash, reordered.Hash(); e != a {
+		t.Errorf("expected reordered catalog to have the same hash; expected %v, got %v", e, a)
+	}
+	if e, a := hash, catalog.Hash(); e != a {
+		t.Errorf("expected hash to be stable; expected %v, got %v", e, a)
+	}
+	if e, a := "service-2", reordered.Services[0].ID; e != a {
+		t.Errorf("expected Hash not to reorder the catalog; expected first service %v, got %v", e, a)
+	}
+
+	reordered.Services[1].Plans[0].Metadata["x"] = float64(3)
+	if reordered.Hash() == hash {
+		t.Error("expected a changed catalog to have a different hash")
+	}
+}
+
+func TestCatalogResponseValidateForVersion(t *testing.T) {
+	maximumPollingDuration := int64(60)
+
+	schemaCatalog := CatalogResponse{
+		Services: []Service{
+			{
+				ID: "service-id",
+				Plans: []Plan{
+					{ID: "plain-plan-id"},
+					{ID: "schema-plan-id", Schemas: &Schemas{}},
+				},
+			},
+		},
+	}
+	alphaCatalog := CatalogResponse{
+		Services: []Service{
+			{
+				ID:                   "service-id",
+				InstancesRetrievable: true,
+				BindingsRetrievable:  true,
+				Plans: []Plan{
+					{
+						ID:                     "plan-id",
+						PlanUpdateable:         truePtr(),
+						MaximumPollingDuration: &maximumPollingDuration,
+						MaintenanceInfo:        &MaintenanceInfo{Version: "1.0.0"},
+					},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		catalog        CatalogResponse
+		version        APIVersion
+		alpha          bool
+		expectedErrors []string
+	}{
+		{
+			name:    "schemas at 2.12",
+			catalog: schemaCatalog,
+			version: Version2_12(),
+			expectedErrors: []string{
+				`service "service-id", plan "schema-plan-id": schemas requires API version >= 2.13, got 2.12`,
+			},
+		},
+		{
+			name:    "schemas at 2.13",
+			catalog: schemaCatalog,
+			version: Version2_13(),
+		},
+		{
+			name:    "alpha fields without alpha features",
+			catalog: alphaCatalog,
+			version: LatestAPIVersion(),
+			expectedErrors: []string{
+				`service "service-id": instances_retrievable requires alpha features`,
+				`service "service-id": bindings_retrievable requires alpha features`,
+				`service "service-id", plan "plan-id": plan_updateable requires alpha features`,
+				`service "service-id", plan "plan-id": maximum_polling_duration requires alpha features`,
+				`service "service-id", plan "plan-id": maintenance_info requires alpha features`,
+			},
+		},
+		{
+			name:    "alpha fields with alpha features",
+			catalog: alphaCatalog,
+			version: LatestAPIVersion(),
+			alpha:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.catalog.ValidateForVersion(tc.version, tc.alpha)
+		if len(tc.expectedErrors) == 0 {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%v: expected error, got none", tc.name)
+			continue
+		}
+		if e, a := strings.Join(tc.expectedErrors, "\n"), err.Error(); e != a {
+			t.Errorf("%v: unexpected error; expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestPlanCosts(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata map[string]interface{}
+		expected []PlanCost
+		// expectedErr is a prefix of the expected error message.
+		expectedErr string
+	}{
+		{
+			name:     "no metadata",
+			expected: []PlanCost{},
+		},
+		{
+			name:     "no costs",
+			metadata: map[string]interface{}{"bullets": []interface{}{"fast"}},
+			expected: []PlanCost{},
+		},
+		{
+			name: "costs",
+			metadata: map[string]interface{}{
+				"costs": []interface{}{
+					map[string]interface{}{
+						"amount": map[string]interface{}{"usd": 99.0, "eur": 49.5},
+						"unit":   "MONTHLY",
+					},
+					map[string]interface{}{
+						"amount": map[string]interface{}{"usd": 0.99},
+						"unit":   "1GB of messages over 20GB",
+					},
+				},
+			},
+			expected: []PlanCost{
+				{Amount: map[string]float64{"usd": 99, "eur": 49.5}, Unit: "MONTHLY"},
+				{Amount: map[string]float64{"usd": 0.99}, Unit: "1GB of messages over 20GB"},
+			},
+		},
+		{
+			name:        "costs not a list",
+			metadata:    map[string]interface{}{"costs": "free"},
+			expectedErr: `plan "plan-id": malformed costs: json: cannot unmarshal string`,
+		},
+		{
+			name: "amount not a number",
+			metadata: map[string]interface{}{
+				"costs": []interface{}{
+					map[string]interface{}{
+						"amount": map[string]interface{}{"usd": "99"},
+						"unit":   "MONTHLY",
+					},
+				},
+			},
+			expectedErr: `plan "plan-id": malformed costs: json: cannot unmarshal string`,
+		},
+		{
+			name: "missing amount",
+			metadata: map[string]interface{}{
+				"costs": []interface{}{
+					map[string]interface{}{"unit": "MONTHLY"},
+				},
+			},
+			expectedErr: `plan "plan-id": malformed costs: cost 0 has no amount`,
+		},
+		{
+			name: "missing unit",
+			metadata: map[string]interface{}{
+				"costs": []interface{}{
+					map[string]interface{}{
+						"amount": map[string]interface{}{"usd": 99.0},
+					},
+				},
+			},
+			expectedErr: `plan "plan-id": malformed costs: cost 0 has no unit`,
+		},
+	}
+
+	for _, tc := range cases {
+		plan := Plan{ID: "plan-id", Metadata: tc.metadata}
+		costs, err := plan.Costs()
+		if tc.expectedErr != "" {
+			if err == nil {
+				t.Errorf("%v: expected error, got none", tc.name)
+			} else if e, a := tc.expectedErr, err.Error(); !strings.HasPrefix(a, e) {
+				t.Errorf("%v: unexpected error; expected %q, got %q", tc.name, e, a)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := tc.expected, costs; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected costs; expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
+
+func TestPlanIsBindingRotatable(t *testing.T) {
+	cases := []struct {
+		name     string
+		plan     Plan
+		expected bool
+	}{
+		{name: "unset", plan: Plan{}, expected: false},
+		{name: "true", plan: Plan{BindingRotatable: truePtr()}, expected: true},
+		{name: "false", plan: Plan{BindingRotatable: falsePtr()}, expected: false},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, tc.plan.IsBindingRotatable(); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestCatalogServicesByTag(t *testing.T) {
+	catalog := CatalogResponse{
+		Services: []Service{
+			{ID: "sql-id", Name: "sql", Tags: []string{"database", "SQL", "database"}},
+			{ID: "redis-id", Name: "redis", Tags: []string{"cache", "database"}},
+			{ID: "queue-id", Name: "queue"},
+			{ID: "sql-id", Name: "sql-duplicate", Tags: []string{"duplicate"}},
+		},
+	}
+	index := BuildCatalogIndex(catalog)
+
+	cases := []struct {
+		tag      string
+		expected []string
+	}{
+		{tag: "database", expected: []string{"sql-id", "redis-id"}},
+		{tag: "cache", expected: []string{"redis-id"}},
+		{tag: "SQL", expected: []string{"sql-id"}},
+		{tag: "sql", expected: nil},
+		{tag: "Database", expected: nil},
+		{tag: "", expected: nil},
+	}
+	for _, tc := range cases {
+		for name, services := range map[string][]Service{
+			"catalog": catalog.ServicesByTag(tc.tag),
+			"index":   index.ServicesByTag(tc.tag),
+		} {
+			var ids []string
+			for _, service := range services {
+				ids = append(ids, service.ID)
+			}
+			if e, a := tc.expected, ids; !reflect.DeepEqual(e, a) {
+				t.Errorf("%v, %q: expected %v, got %v", name, tc.tag, e, a)
+			}
+		}
+	}
+}
+
+func TestCatalogIndexLookups(t *testing.T) {
+	catalog := CatalogResponse{
+		Services: []Service{
+			{ID: "sql-id", Name: "sql"},
+			{ID: "redis-id", Name: "redis"},
+			{ID: "sql-id", Name: "redis"},
+		},
+	}
+	index := BuildCatalogIndex(catalog)
+
+	if service := index.FindService("sql-id"); service == nil || service.Name != "sql" {
+		t.Errorf("expected the first service with ID sql-id, got %+v", service)
+	}
+	if service := index.FindService("SQL-ID"); service != nil {
+		t.Errorf("expected no service for a differently cased ID, got %+v", service)
+	}
+	if service := index.FindServiceByName("redis"); service == nil || service.ID != "redis-id" {
+		t.Errorf("expected the first service named redis, got %+v", service)
+	}
+	if service := index.FindServiceByName("Redis"); service != nil {
+		t.Errorf("expected no service for a differently cased name, got %+v", service)
+	}
+	if service := index.FindService("missing"); service != nil {
+		t.Errorf("expected no service, got %+v", service)
+	}
+}
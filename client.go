@@ -18,6 +18,7 @@ package v2
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -25,9 +26,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -46,6 +51,9 @@ const (
 	// PollingDelayHeader is the header used by the brokers to tell the clients
 	// how many seconds they should wait before retrying the polling
 	PollingDelayHeader = "Retry-After"
+	// WarningHeader is the header used by brokers to report non-fatal issues,
+	// such as deprecations, with an otherwise successful response.
+	WarningHeader = "Warning"
 
 	catalogURL                 = "%s/v2/catalog"
 	serviceInstanceURLFmt      = "%s/v2/service_instances/%s"
@@ -58,6 +66,65 @@ const (
 // NewClient is a CreateFunc for creating a new functional Client and
 // implements the CreateFunc interface.
 func NewClient(config *ClientConfiguration) (Client, error) {
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		Name:                             config.Name,
+		URL:                              strings.TrimRight(config.URL, "/"),
+		APIVersion:                       config.APIVersion,
+		EnableAlphaFeatures:              config.EnableAlphaFeatures,
+		Verbose:                          config.Verbose,
+		RequireLastOperationIDs:          config.RequireLastOperationIDs,
+		RequestIdentityVerification:      config.RequestIdentityVerification,
+		FollowRedirects:                  config.FollowRedirects,
+		GenerateInstanceIDs:              config.GenerateInstanceIDs,
+		AcceptOperationKeyAliases:        config.AcceptOperationKeyAliases,
+		MaxClockSkew:                     config.MaxClockSkew,
+		ValidateParametersAgainstSchemas: config.ValidateParametersAgainstSchemas,
+		MaxLogBodyBytes:                  config.MaxLogBodyBytes,
+		BindingsListPath:                 config.BindingsListPath,
+		SignRequest:                      config.SignRequest,
+		WarnOnDeprecatedFields:           config.WarnOnDeprecatedFields,
+		AlwaysAcceptIncomplete:           config.AlwaysAcceptIncomplete,
+		UseJSONNumbers:                   config.UseJSONNumbers,
+		DefaultContext:                   config.DefaultContext,
+		TransformRequestBody:             config.TransformRequestBody,
+		CorrelationIDHeader:              config.CorrelationIDHeader,
+		CorrelationID:                    config.CorrelationID,
+		AcceptEmptyGetResponses:          config.AcceptEmptyGetResponses,
+		SlowRequestThreshold:             config.SlowRequestThreshold,
+		MaxOriginatingIdentityBytes:      config.MaxOriginatingIdentityBytes,
+		ErrorBodyExcerptBytes:            config.ErrorBodyExcerptBytes,
+		BodyReadTimeout:                  config.BodyReadTimeout,
+		DryRun:                           config.DryRun,
+		DiscoveryPath:                    config.DiscoveryPath,
+		requestSlots:                     newRequestSlots(config.MaxConcurrentRequests),
+		httpClient:                       httpClient,
+		clock:                            time.Now,
+	}
+	c.doRequestFunc = c.doRequest
+	httpClient.CheckRedirect = c.checkRedirect
+
+	if err := validateAuthConfig(config.AuthConfig); err != nil {
+		return nil, err
+	}
+	c.AuthConfig = config.AuthConfig
+
+	return c, nil
+}
+
+var _ CreateFunc = NewClient
+
+// newHTTPClient returns an HTTP client with a new transport set up according
+// to the timeout and TLS settings of the given configuration.
+func newHTTPClient(config *ClientConfiguration) (*http.Client, error) {
+	if err := validateTLSConfig(config); err != nil {
+		return nil, err
+	}
+
 	httpClient := &http.Client{
 		Timeout: time.Duration(config.TimeoutSeconds) * time.Second,
 	}
@@ -84,42 +151,109 @@ func NewClient(config *ClientConfiguration) (Client, error) {
 	if config.Insecure {
 		transport.TLSClientConfig.InsecureSkipVerify = true
 	}
+	if config.TLSRenegotiation != tls.RenegotiateNever {
+		transport.TLSClientConfig.Renegotiation = config.TLSRenegotiation
+	}
 	if len(config.CAData) != 0 {
 		if transport.TLSClientConfig.RootCAs == nil {
 			transport.TLSClientConfig.RootCAs = x509.NewCertPool()
 		}
 		transport.TLSClientConfig.RootCAs.AppendCertsFromPEM(config.CAData)
 	}
-	if transport.TLSClientConfig.InsecureSkipVerify && transport.TLSClientConfig.RootCAs != nil {
-		return nil, errors.New("Cannot specify root CAs and to skip TLS verification")
-	}
 	httpClient.Transport = transport
 
-	c := &client{
-		Name:                config.Name,
-		URL:                 strings.TrimRight(config.URL, "/"),
-		APIVersion:          config.APIVersion,
-		EnableAlphaFeatures: config.EnableAlphaFeatures,
-		Verbose:             config.Verbose,
-		httpClient:          httpClient,
+	return httpClient, nil
+}
+
+// validateTLSConfig returns an error listing every inconsistency between the
+// TLS settings of the given configuration, so that a misconfiguration fails
+// when the client is built rather than on the first request.
+func validateTLSConfig(config *ClientConfiguration) error {
+	var errs []error
+
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
 	}
-	c.doRequestFunc = c.doRequest
 
-	if config.AuthConfig != nil {
-		if config.AuthConfig.BasicAuthConfig == nil && config.AuthConfig.BearerConfig == nil {
-			return nil, errors.New("Non-nil AuthConfig cannot be empty")
-		}
-		if config.AuthConfig.BasicAuthConfig != nil && config.AuthConfig.BearerConfig != nil {
-			return nil, errors.New("Only one AuthConfig implementation must be set at a time")
+	insecure := config.Insecure || tlsConfig.InsecureSkipVerify
+	if insecure && (len(config.CAData) != 0 || tlsConfig.RootCAs != nil) {
+		errs = append(errs, errors.New("Cannot specify root CAs and to skip TLS verification"))
+	}
+
+	if len(config.CAData) != 0 && !x509.NewCertPool().AppendCertsFromPEM(config.CAData) {
+		errs = append(errs, errors.New("CAData holds no PEM-encoded certificate"))
+	}
+
+	if tlsConfig.MinVersion != 0 && tlsConfig.MaxVersion != 0 && tlsConfig.MinVersion > tlsConfig.MaxVersion {
+		errs = append(errs, fmt.Errorf("TLS minimum version %s is above maximum version %s", tls.VersionName(tlsConfig.MinVersion), tls.VersionName(tlsConfig.MaxVersion)))
+	}
+
+	for i, certificate := range tlsConfig.Certificates {
+		switch {
+		case len(certificate.Certificate) == 0 && certificate.PrivateKey != nil:
+			errs = append(errs, fmt.Errorf("client certificate %d has a private key but no certificate", i))
+		case len(certificate.Certificate) == 0:
+			errs = append(errs, fmt.Errorf("client certificate %d is empty", i))
+		case certificate.PrivateKey == nil:
+			errs = append(errs, fmt.Errorf("client certificate %d has no private key", i))
 		}
+	}
 
-		c.AuthConfig = config.AuthConfig
+	if config.TLSRenegotiation < tls.RenegotiateNever || config.TLSRenegotiation > tls.RenegotiateFreelyAsClient {
+		errs = append(errs, fmt.Errorf("invalid TLS renegotiation support %d", config.TLSRenegotiation))
 	}
 
-	return c, nil
+	return errors.Join(errs...)
 }
 
-var _ CreateFunc = NewClient
+// validateAuthConfig returns an error if the given auth configuration is set
+// but does not hold exactly one implementation.
+func validateAuthConfig(authConfig *AuthConfig) error {
+	if authConfig == nil {
+		return nil
+	}
+	if authConfig.BasicAuthConfig == nil && authConfig.BearerConfig == nil {
+		return errors.New("Non-nil AuthConfig cannot be empty")
+	}
+	if authConfig.BasicAuthConfig != nil && authConfig.BearerConfig != nil {
+		return errors.New("Only one AuthConfig implementation must be set at a time")
+	}
+	return nil
+}
+
+// Refresh rebuilds the transport of the client from the timeout, TLS and
+// auth settings of the given configuration, and closes the idle connections
+// of the previous transport.  Other settings, such as the URL or the API
+// version of the broker, are left unchanged.  Requests in flight complete
+// on the previous transport.
+//
+// The transport and credentials are shared by c, the client it was derived
+// from with WithAPIVersion, if any, and all the clients derived from that
+// one, which therefore all follow the refresh.
+func (c *client) Refresh(config *ClientConfiguration) error {
+	c = c.rootClient()
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return err
+	}
+	if err := validateAuthConfig(config.AuthConfig); err != nil {
+		return err
+	}
+	httpClient.CheckRedirect = c.checkRedirect
+
+	c.connLock.Lock()
+	previous := c.httpClient
+	c.httpClient = httpClient
+	c.AuthConfig = config.AuthConfig
+	c.connLock.Unlock()
+
+	if previous != nil {
+		previous.CloseIdleConnections()
+	}
+	return nil
+}
 
 type doRequestFunc func(request *http.Request) (*http.Response, error)
 
@@ -131,12 +265,111 @@ type client struct {
 	AuthConfig          *AuthConfig
 	EnableAlphaFeatures bool
 	Verbose             bool
+	// RequireLastOperationIDs is whether last operation requests must carry
+	// a ServiceID and PlanID.
+	RequireLastOperationIDs bool
+	// RequestIdentityVerification is how echoed request identities are
+	// verified.
+	RequestIdentityVerification RequestIdentityVerification
+	// FollowRedirects is whether redirects to another host are followed.
+	FollowRedirects bool
+	// GenerateInstanceIDs is whether empty instance IDs are generated on
+	// provision.
+	GenerateInstanceIDs bool
+	// AcceptOperationKeyAliases is whether aliases of the 'operation' field
+	// are accepted in asynchronous responses.
+	AcceptOperationKeyAliases bool
+	// MaxClockSkew is the maximum tolerated difference between the broker's
+	// Date header and the client's clock.
+	MaxClockSkew time.Duration
+	// ValidateParametersAgainstSchemas is whether request parameters are
+	// validated against the schemas of the last fetched catalog.
+	ValidateParametersAgainstSchemas bool
+	// MaxLogBodyBytes is the maximum number of bytes of a response body
+	// logged in verbose mode, or zero for no limit.
+	MaxLogBodyBytes int
+	// BindingsListPath is the path of the non-standard endpoint listing the
+	// bindings of an instance, or empty if the broker has none.
+	BindingsListPath string
+	// SignRequest is called with each fully built request before it is
+	// sent, if set.
+	SignRequest func(*http.Request) error
+	// WarnOnDeprecatedFields is whether deprecated fields set in requests
+	// are logged.
+	WarnOnDeprecatedFields bool
+	// AlwaysAcceptIncomplete is whether requests are sent with
+	// accepts_incomplete=true whatever their AcceptsIncomplete field.
+	AlwaysAcceptIncomplete bool
+	// UseJSONNumbers is whether numbers in free-form response maps are
+	// unmarshaled as json.Number rather than float64.
+	UseJSONNumbers bool
+	// DefaultContext holds the context keys sent with every provision,
+	// update and bind request unless the request sets them.
+	DefaultContext map[string]interface{}
+	// TransformRequestBody, if set, is called with each marshaled request
+	// body before it is sent.
+	TransformRequestBody func(operation string, body []byte) ([]byte, error)
+	// CorrelationIDHeader is the name of the header carrying the correlation
+	// ID of each request, or empty if none is sent.
+	CorrelationIDHeader string
+	// CorrelationID returns the correlation ID to send with a request.
+	CorrelationID func(*http.Request) string
+	// AcceptEmptyGetResponses is whether an empty 200 response to
+	// GetInstance or GetBinding is a zero-valued response.
+	AcceptEmptyGetResponses bool
+	// SlowRequestThreshold is the round trip time over which a request is
+	// logged as slow.  Zero disables the warning.
+	SlowRequestThreshold time.Duration
+	// MaxOriginatingIdentityBytes is the maximum length of the
+	// originating identity header value, or zero for no limit.
+	MaxOriginatingIdentityBytes int
+	// ErrorBodyExcerptBytes is the maximum number of bytes of a response body
+	// included in unmarshalling errors, or zero for none.
+	ErrorBodyExcerptBytes int
+	// BodyReadTimeout is the time allowed to read a response body once its
+	// headers are received, or zero for no limit.
+	BodyReadTimeout time.Duration
+	// DryRun is whether requests are answered with a canned success response
+	// instead of being sent.
+	DryRun bool
+	// DiscoveryPath is the path of the non-standard discovery endpoint, or
+	// empty if the broker has none.
+	DiscoveryPath string
 
+	// requestSlots bounds the requests in flight, if MaxConcurrentRequests
+	// is set.  It is shared by the clients derived by WithAPIVersion.
+	requestSlots chan struct{}
+
+	// connLock guards httpClient and AuthConfig, which are replaced by
+	// Refresh.
+	connLock      sync.RWMutex
 	httpClient    *http.Client
 	doRequestFunc doRequestFunc
+	// clock returns the current time and is used by all time-dependent
+	// logic in the client.  Tests may replace it with a fake clock.
+	clock func() time.Time
+	// newTimer, if set, replaces time.NewTimer so that tests can control
+	// timeouts.
+	newTimer func(time.Duration) *time.Timer
+
+	// root is the client this one was derived from by WithAPIVersion, if
+	// any.  The cached schemas, transport and credentials of the root are
+	// shared by the clients derived from it, whose own are unused.
+	root *client
+
+	// catalogLock guards schemas.
+	catalogLock sync.RWMutex
+	// schemas holds copies of the plan schemas of the catalog most recently
+	// returned by GetCatalog, kept when ValidateParametersAgainstSchemas is
+	// set.  They are copied so that callers may modify the returned catalog.
+	schemas map[planKey]*Schemas
 }
 
 var _ Client = &client{}
+var _ Refresher = &client{}
+var _ BindingLister = &client{}
+var _ InstanceChecker = &client{}
+var _ Discoverer = &client{}
 
 // This file contains shared methods used by each interface method of the
 // Client interface.  Individual interface methods are in the following files:
@@ -149,17 +382,113 @@ var _ Client = &client{}
 // Bind: bind.go
 // Unbind: unbind.go
 // RotateBinding: rotate_binding.go
+// GetBindings: get_bindings.go
+// Discover: discover.go
 
 const (
 	contentType = "Content-Type"
 	jsonType    = "application/json"
+	dateHeader  = "Date"
 )
 
+func (c *client) BrokerURL() string {
+	return c.URL
+}
+
+func (c *client) BrokerAPIVersion() APIVersion {
+	return c.APIVersion
+}
+
+func (c *client) AlphaEnabled() bool {
+	return c.EnableAlphaFeatures
+}
+
+// WithAPIVersion returns a Client that behaves like c, sharing its
+// configuration, but uses the given API version for the calls made through
+// it: version is sent in the X-Broker-API-Version header and gates which
+// operations and fields are allowed.  c itself is left unchanged, so this
+// allows overriding the API version of individual calls to a broker that
+// supports different versions for different endpoints.
+//
+// Only clients created by NewClient can be derived; any other Client, such
+// as a fake, is returned unchanged.
+func WithAPIVersion(c Client, version APIVersion) Client {
+	original, ok := c.(*client)
+	if !ok {
+		return c
+	}
+
+	derived := &client{
+		Name:                             original.Name,
+		URL:                              original.URL,
+		APIVersion:                       version,
+		EnableAlphaFeatures:              original.EnableAlphaFeatures,
+		Verbose:                          original.Verbose,
+		RequireLastOperationIDs:          original.RequireLastOperationIDs,
+		RequestIdentityVerification:      original.RequestIdentityVerification,
+		FollowRedirects:                  original.FollowRedirects,
+		GenerateInstanceIDs:              original.GenerateInstanceIDs,
+		AcceptOperationKeyAliases:        original.AcceptOperationKeyAliases,
+		MaxClockSkew:                     original.MaxClockSkew,
+		ValidateParametersAgainstSchemas: original.ValidateParametersAgainstSchemas,
+		MaxLogBodyBytes:                  original.MaxLogBodyBytes,
+		BindingsListPath:                 original.BindingsListPath,
+		SignRequest:                      original.SignRequest,
+		WarnOnDeprecatedFields:           original.WarnOnDeprecatedFields,
+		AlwaysAcceptIncomplete:           original.AlwaysAcceptIncomplete,
+		UseJSONNumbers:                   original.UseJSONNumbers,
+		DefaultContext:                   original.DefaultContext,
+		TransformRequestBody:             original.TransformRequestBody,
+		CorrelationIDHeader:              original.CorrelationIDHeader,
+		CorrelationID:                    original.CorrelationID,
+		AcceptEmptyGetResponses:          original.AcceptEmptyGetResponses,
+		SlowRequestThreshold:             original.SlowRequestThreshold,
+		MaxOriginatingIdentityBytes:      original.MaxOriginatingIdentityBytes,
+		ErrorBodyExcerptBytes:            original.ErrorBodyExcerptBytes,
+		BodyReadTimeout:                  original.BodyReadTimeout,
+		DryRun:                           original.DryRun,
+		DiscoveryPath:                    original.DiscoveryPath,
+		requestSlots:                     original.requestSlots,
+		doRequestFunc:                    original.doRequestFunc,
+		clock:                            original.clock,
+		newTimer:                         original.newTimer,
+		root:                             original.rootClient(),
+	}
+	return derived
+}
+
+// rootClient returns the client holding the cached schemas, transport and
+// credentials used by c.
+func (c *client) rootClient() *client {
+	if c.root != nil {
+		return c.root
+	}
+	return c
+}
+
 // prepareAndDo prepares a request for the given method, URL, and
 // message body, and executes the request, returning an http.Response or an
 // error.  Errors returned from this function represent http-layer errors and
-// not errors in the Open Service Broker API.
-func (c *client) prepareAndDo(method, URL string, params map[string]string, body interface{}, originatingIdentity *OriginatingIdentity) (*http.Response, error) {
+// not errors in the Open Service Broker API.  operation is the name of the
+// Client method the request is made for, passed to TransformRequestBody.
+//
+// The body is marshaled with encoding/json, which writes the keys of maps,
+// such as request Parameters and Context, in sorted order.  The same request
+// therefore always produces a byte-identical body, suitable for signing or
+// for use as a cache key.
+func (c *client) prepareAndDo(operation, method, URL string, params map[string]string, body interface{}, originatingIdentity *OriginatingIdentity) (*http.Response, error) {
+	return c.prepareAndDoWithContext(context.Background(), operation, method, URL, params, body, originatingIdentity)
+}
+
+// prepareAndDoWithContext is like prepareAndDo but binds the request to the
+// given context, so that it is abandoned once the context is done.  The
+// resulting error is returned as is, never as an HTTPStatusCodeError, so
+// that callers can tell it apart from a broker failure with
+// errors.Is(err, context.Canceled) or context.DeadlineExceeded.  If
+// originatingIdentity is nil, the one carried by ctx, if any, is sent.
+func (c *client) prepareAndDoWithContext(ctx context.Context, operation, method, URL string, params map[string]string, body interface{}, originatingIdentity *OriginatingIdentity) (*http.Response, error) {
+	originatingIdentity = withContextOriginatingIdentity(ctx, originatingIdentity)
+
 	var bodyReader io.Reader
 
 	if body != nil {
@@ -168,10 +497,17 @@ func (c *client) prepareAndDo(method, URL string, params map[string]string, body
 			return nil, err
 		}
 
+		if c.TransformRequestBody != nil {
+			bodyBytes, err = c.TransformRequestBody(operation, bodyBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	request, err := http.NewRequest(method, URL, bodyReader)
+	request, err := http.NewRequestWithContext(ctx, method, URL, bodyReader)
 	if err != nil {
 		return nil, err
 	}
@@ -181,21 +517,19 @@ func (c *client) prepareAndDo(method, URL string, params map[string]string, body
 		request.Header.Set(contentType, jsonType)
 	}
 
-	if c.AuthConfig != nil {
-		if c.AuthConfig.BasicAuthConfig != nil {
-			basicAuth := c.AuthConfig.BasicAuthConfig
-			request.SetBasicAuth(basicAuth.Username, basicAuth.Password)
-		} else if c.AuthConfig.BearerConfig != nil {
-			bearer := c.AuthConfig.BearerConfig
-			request.Header.Set("Authorization", "Bearer "+bearer.Token)
-		}
-	}
+	c.setAuthorization(request)
 
 	requestId := uuid.New()
 	request.Header.Set(RequestIdentityheader, requestId.String())
 
+	if c.CorrelationIDHeader != "" && c.CorrelationID != nil {
+		if correlationID := c.CorrelationID(request); correlationID != "" {
+			request.Header.Set(c.CorrelationIDHeader, correlationID)
+		}
+	}
+
 	if c.APIVersion.AtLeast(Version2_13()) && originatingIdentity != nil {
-		headerValue, err := buildOriginatingIdentityHeaderValue(originatingIdentity)
+		headerValue, err := buildOriginatingIdentityHeaderValue(originatingIdentity, c.MaxOriginatingIdentityBytes)
 		if err != nil {
 			return nil, err
 		}
@@ -210,30 +544,299 @@ func (c *client) prepareAndDo(method, URL string, params map[string]string, body
 		request.URL.RawQuery = q.Encode()
 	}
 
+	if c.SignRequest != nil {
+		if err := c.SignRequest(request); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Verbose {
+		klog.Infof("broker %q: doing %s request to %q", c.Name, method, request.URL)
+	}
+
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := c.now()
+	var response *http.Response
+	if c.DryRun {
+		response, err = dryRunResponse(operation, request)
+	} else {
+		response, err = c.doRequestFunc(request)
+	}
+	elapsed := c.now().Sub(start)
+	if c.Verbose {
+		klog.Infof("broker %q: round trip to %q took %v", c.Name, request.URL, elapsed)
+	}
+	if c.SlowRequestThreshold > 0 && elapsed > c.SlowRequestThreshold {
+		klog.Warningf("broker %q: slow %s: %s request to %q took %v, over %v", c.Name, operation, method, request.URL, elapsed, c.SlowRequestThreshold)
+	}
+	if err != nil {
+		release()
+		return response, err
+	}
+	response.Body = &slotReleasingBody{ReadCloser: response.Body, release: release}
+
 	if c.Verbose {
-		klog.Infof("broker %q: doing request to %q", c.Name, URL)
+		for _, warning := range responseWarnings(response) {
+			klog.Warningf("broker %q: warning in response to %s %q: %s", c.Name, method, request.URL, warning)
+		}
 	}
 
-	return c.doRequestFunc(request)
+	if err := c.verifyRequestIdentity(requestId.String(), response); err != nil {
+		_ = drainReader(response.Body)
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// echoedIDs holds the instance and binding IDs that some brokers echo in the
+// bodies of their responses, although the spec defines no such fields.
+type echoedIDs struct {
+	InstanceID *string `json:"instance_id,omitempty"`
+	BindingID  *string `json:"binding_id,omitempty"`
+}
+
+// verify returns an IDMismatchError if an echoed ID differs from the
+// requested one, so that a response about another instance or binding is
+// never returned.  An empty bindingID is not verified.
+func (e echoedIDs) verify(instanceID, bindingID string) error {
+	if e.InstanceID != nil && *e.InstanceID != instanceID {
+		return IDMismatchError{Field: "instance_id", Requested: instanceID, Echoed: *e.InstanceID}
+	}
+	if bindingID != "" && e.BindingID != nil && *e.BindingID != bindingID {
+		return IDMismatchError{Field: "binding_id", Requested: bindingID, Echoed: *e.BindingID}
+	}
+	return nil
+}
+
+// verifyRequestIdentity compares the request identity echoed in the given
+// response against the one sent, according to the client's
+// RequestIdentityVerification mode.
+func (c *client) verifyRequestIdentity(sent string, response *http.Response) error {
+	if c.RequestIdentityVerification == RequestIdentityVerificationNone {
+		return nil
+	}
+
+	received := response.Header.Get(RequestIdentityheader)
+	if received == "" || received == sent {
+		return nil
+	}
+
+	meta := ResponseMeta{
+		SentRequestIdentity:     sent,
+		ReceivedRequestIdentity: received,
+		Warnings:                responseWarnings(response),
+	}
+	if c.RequestIdentityVerification == RequestIdentityVerificationStrict {
+		return RequestIdentityMismatchError{Meta: meta}
+	}
+
+	klog.Warningf("broker %q: request identity mismatch: sent %q, received %q", c.Name, sent, received)
+	return nil
+}
+
+// responseWarnings returns the values of the Warning headers of the given
+// response, if any.
+func responseWarnings(response *http.Response) []string {
+	return response.Header.Values(WarningHeader)
 }
 
 func (c *client) doRequest(request *http.Request) (*http.Response, error) {
-	return c.httpClient.Do(request)
+	root := c.rootClient()
+	root.connLock.RLock()
+	httpClient := root.httpClient
+	root.connLock.RUnlock()
+
+	return httpClient.Do(request)
+}
+
+// setAuthorization sets the credentials of the client's auth configuration,
+// if any, on the given request.
+func (c *client) setAuthorization(request *http.Request) {
+	root := c.rootClient()
+	root.connLock.RLock()
+	authConfig := root.AuthConfig
+	root.connLock.RUnlock()
+
+	if authConfig == nil {
+		return
+	}
+
+	if authConfig.BasicAuthConfig != nil {
+		basicAuth := authConfig.BasicAuthConfig
+		request.SetBasicAuth(basicAuth.Username, basicAuth.Password)
+	} else if authConfig.BearerConfig != nil {
+		bearer := authConfig.BearerConfig
+		request.Header.Set("Authorization", "Bearer "+bearer.Token)
+	}
+}
+
+// maxRedirects is the number of redirects the client follows before giving
+// up, matching the default of net/http.
+const maxRedirects = 10
+
+// checkRedirect implements the http.Client CheckRedirect policy of the
+// client.  Redirects to another host are refused unless FollowRedirects is
+// set, in which case the auth configuration, which net/http strips on
+// cross-host redirects, is re-attached.
+func (c *client) checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	original := via[0]
+	if request.URL.Host == original.URL.Host {
+		return nil
+	}
+
+	if !c.FollowRedirects {
+		return fmt.Errorf("refusing redirect from host %q to host %q", original.URL.Host, request.URL.Host)
+	}
+
+	if c.Verbose {
+		klog.Infof("broker %q: following redirect to %q", c.Name, request.URL)
+	}
+	c.setAuthorization(request)
+
+	return nil
+}
+
+// now returns the current time according to the client's clock.
+func (c *client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock()
+}
+
+// timer returns a timer firing after d according to the client's clock.
+func (c *client) timer(d time.Duration) *time.Timer {
+	if c.newTimer == nil {
+		return time.NewTimer(d)
+	}
+	return c.newTimer(d)
+}
+
+// referenceTime returns the time against which HTTP dates in the given
+// response should be compared.  This is the response's Date header, so that
+// clock skew between the client and the broker does not distort delays,
+// clamped to within MaxClockSkew of the client's clock if set.  The client's
+// clock is used when the Date header is absent or invalid.
+func (c *client) referenceTime(response *http.Response) time.Time {
+	now := c.now()
+
+	date, err := http.ParseTime(response.Header.Get(dateHeader))
+	if err != nil {
+		return now
+	}
+
+	if c.MaxClockSkew > 0 {
+		if earliest := now.Add(-c.MaxClockSkew); date.Before(earliest) {
+			return earliest
+		}
+		if latest := now.Add(c.MaxClockSkew); date.After(latest) {
+			return latest
+		}
+	}
+
+	return date
+}
+
+// maxPollDelaySeconds is the largest number of seconds in a Retry-After
+// header that can be represented as a time.Duration.
+const maxPollDelaySeconds = float64(math.MaxInt64 / int64(time.Second))
+
+// pollDelay returns the delay requested by the broker in the Retry-After
+// header of the given response, or nil if the header is absent or invalid.
+// HTTP dates are compared to the response's reference time.
+func (c *client) pollDelay(response *http.Response) *time.Duration {
+	delay, ok := parseRetryAfter(response.Header.Get(PollingDelayHeader), func() time.Time {
+		return c.referenceTime(response)
+	})
+	if !ok {
+		return nil
+	}
+	return &delay
+}
+
+// ParseRetryAfter returns the delay requested in the Retry-After header of
+// the given response, and whether the header holds a valid delay.  The
+// header may either be a positive number of seconds, possibly fractional as
+// some brokers send, or an HTTP date, in which case the delay is computed
+// relative to the Date header of the response, or to the current time if it
+// has none, and is zero if the date has passed.  It is the parsing used to
+// populate the PollDelay field of responses.
+func ParseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	return parseRetryAfter(resp.Header.Get(PollingDelayHeader), func() time.Time {
+		if date, err := http.ParseTime(resp.Header.Get(dateHeader)); err == nil {
+			return date
+		}
+		return time.Now()
+	})
+}
+
+// parseRetryAfter parses the given Retry-After header value.  reference is
+// only called for HTTP dates, and returns the time they are compared to.
+func parseRetryAfter(value string, reference func() time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if delay, err := strconv.ParseFloat(value, 64); err == nil {
+		if !(delay > 0) || delay > maxPollDelaySeconds {
+			return 0, false
+		}
+		return time.Duration(delay * float64(time.Second)), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		duration := date.Sub(reference())
+		if duration < 0 {
+			duration = 0
+		}
+		return duration, true
+	}
+
+	return 0, false
 }
 
 // unmarshalResponse unmarshals the response body of the given response into
-// the given object or returns an error.
+// the given object or returns an error.  The Content-Type header of the
+// response is intentionally not consulted: some brokers return JSON bodies
+// without declaring them as such, and the body is always decoded as JSON.
+// A body holding more than one top-level JSON value, or trailing data after
+// the value, is rejected with an error.
 func (c *client) unmarshalResponse(response *http.Response, obj interface{}) error {
-	body, err := io.ReadAll(response.Body)
+	start := c.now()
+	body, err := c.readResponseBody(response)
 	if err != nil {
 		return err
 	}
 
+	return c.unmarshalBody(body, obj, c.now().Sub(start))
+}
+
+// unmarshalBody unmarshals the given response body, which took readTime to
+// read, into the given object like unmarshalResponse.  If the client is
+// Verbose, the time spent reading and unmarshalling the body is logged.
+func (c *client) unmarshalBody(body []byte, obj interface{}, readTime time.Duration) error {
 	if c.Verbose {
-		klog.Infof("broker %q: response body: %v, type: %T", c.Name, string(body), obj)
+		klog.Infof("broker %q: response body: %v, type: %T", c.Name, truncateForLog(body, c.MaxLogBodyBytes), obj)
 	}
 
-	err = json.Unmarshal(body, obj)
+	start := c.now()
+	err := unmarshalJSON(body, obj, c.UseJSONNumbers)
+	if c.Verbose {
+		klog.Infof("broker %q: reading response body took %v, unmarshalling took %v", c.Name, readTime, c.now().Sub(start))
+	}
+	if err != nil && c.ErrorBodyExcerptBytes > 0 {
+		return ResponseUnmarshalError{Err: err, BodyExcerpt: excerptForError(body, c.ErrorBodyExcerptBytes)}
+	}
 	if err != nil {
 		return err
 	}
@@ -241,6 +844,114 @@ func (c *client) unmarshalResponse(response *http.Response, obj interface{}) err
 	return nil
 }
 
+// readResponseBody reads the whole body of the given response.  If
+// BodyReadTimeout is set and the body is not read in time, the body is
+// closed, so that the read is abandoned, and a BodyReadTimeoutError is
+// returned.  Closing the body of a net/http response unblocks pending reads;
+// other bodies may keep being read in the background until they return.
+func (c *client) readResponseBody(response *http.Response) ([]byte, error) {
+	if c.BodyReadTimeout <= 0 {
+		return io.ReadAll(response.Body)
+	}
+
+	type result struct {
+		body []byte
+		err  error
+	}
+	body := response.Body
+	done := make(chan result, 1)
+	go func() {
+		read, err := io.ReadAll(body)
+		done <- result{read, err}
+	}()
+
+	timer := c.timer(c.BodyReadTimeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		return r.body, r.err
+	case <-timer.C:
+		// The read is not awaited, since the body may ignore Close.  The body
+		// is replaced so that the caller never reads it concurrently.
+		_ = body.Close()
+		response.Body = http.NoBody
+		return nil, BodyReadTimeoutError{Timeout: c.BodyReadTimeout}
+	}
+}
+
+// unmarshalGetResponse is like unmarshalResponse for the response to a GET
+// of an instance or binding, except that an empty body leaves obj unchanged
+// instead of failing if AcceptEmptyGetResponses is set.
+func (c *client) unmarshalGetResponse(response *http.Response, obj interface{}) error {
+	if !c.AcceptEmptyGetResponses {
+		return c.unmarshalResponse(response, obj)
+	}
+
+	start := c.now()
+	body, err := c.readResponseBody(response)
+	if err != nil {
+		return err
+	}
+	readTime := c.now().Sub(start)
+	if len(bytes.TrimSpace(body)) == 0 {
+		if c.Verbose {
+			klog.Infof("broker %q: empty response body accepted, type: %T", c.Name, obj)
+		}
+		return nil
+	}
+
+	return c.unmarshalBody(body, obj, readTime)
+}
+
+// unmarshalJSON unmarshals data into obj like json.Unmarshal, except that
+// numbers unmarshaled into interface{} values become json.Number instead of
+// float64 if useNumber is set.
+func unmarshalJSON(data []byte, obj interface{}, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(data, obj)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(obj); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after top-level JSON value")
+	}
+	return nil
+}
+
+// truncateForLog returns body as a string for logging, truncated to max bytes
+// followed by an ellipsis and the total length if it is longer.  A max of
+// zero or less means no truncation.
+func truncateForLog(body []byte, max int) string {
+	if max <= 0 || len(body) <= max {
+		return string(body)
+	}
+	return fmt.Sprintf("%s... (%d bytes total)", body[:max], len(body))
+}
+
+var (
+	// secretFieldPattern matches JSON string fields whose name suggests a
+	// secret, capturing the name and the colon.
+	secretFieldPattern = regexp.MustCompile(`(?i)("[^"]*(?:password|passwd|secret|token|key|credential)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// urlUserinfoPattern matches the userinfo of URLs, capturing the scheme
+	// separator.
+	urlUserinfoPattern = regexp.MustCompile(`(://)[^/@\s"]+@`)
+)
+
+// excerptForError returns the given response body truncated to max bytes,
+// with the values of fields named like secrets and the userinfo of URLs
+// replaced by RedactedValue.  Redaction is best effort: the body may be
+// malformed and cannot be parsed.
+func excerptForError(body []byte, max int) string {
+	redacted := secretFieldPattern.ReplaceAll(body, []byte(`${1}"`+RedactedValue+`"`))
+	redacted = urlUserinfoPattern.ReplaceAll(redacted, []byte("${1}"+RedactedValue+"@"))
+	return truncateForLog(redacted, max)
+}
+
 // handleFailureResponse returns an HTTPStatusCodeError for the given
 // response.
 func (c *client) handleFailureResponse(response *http.Response) error {
@@ -248,6 +959,7 @@ func (c *client) handleFailureResponse(response *http.Response) error {
 
 	httpErr := HTTPStatusCodeError{
 		StatusCode: response.StatusCode,
+		RetryAfter: c.pollDelay(response),
 	}
 
 	brokerResponse := make(map[string]interface{})
@@ -267,7 +979,7 @@ func (c *client) handleFailureResponse(response *http.Response) error {
 	return httpErr
 }
 
-func buildOriginatingIdentityHeaderValue(i *OriginatingIdentity) (string, error) {
+func buildOriginatingIdentityHeaderValue(i *OriginatingIdentity, maxBytes int) (string, error) {
 	if i == nil {
 		return "", nil
 	}
@@ -282,9 +994,40 @@ func buildOriginatingIdentityHeaderValue(i *OriginatingIdentity) (string, error)
 	}
 	encodedValue := base64.StdEncoding.EncodeToString([]byte(i.Value))
 	headerValue := fmt.Sprintf("%v %v", i.Platform, encodedValue)
+	if maxBytes > 0 && len(headerValue) > maxBytes {
+		return "", fmt.Errorf("originating identity header value is %d bytes, over the limit of %d bytes; trim the identity value", len(headerValue), maxBytes)
+	}
 	return headerValue, nil
 }
 
+// ParseOriginatingIdentityHeader parses the value of an
+// X-Broker-API-Originating-Identity header, the inverse of the header value
+// the client sends. The value must be the platform and the base64-encoded
+// JSON identity separated by a single space.
+func ParseOriginatingIdentityHeader(value string) (*OriginatingIdentity, error) {
+	platform, encodedValue, found := strings.Cut(value, " ")
+	if !found {
+		return nil, errors.New("originating identity header must contain a platform and a value separated by a space")
+	}
+	if platform == "" {
+		return nil, errors.New("originating identity platform must not be empty")
+	}
+	if encodedValue == "" {
+		return nil, errors.New("originating identity value must not be empty")
+	}
+	decodedValue, err := base64.StdEncoding.DecodeString(encodedValue)
+	if err != nil {
+		return nil, fmt.Errorf("originating identity value must be base64-encoded: %v", err)
+	}
+	if err := isValidJSON(string(decodedValue)); err != nil {
+		return nil, fmt.Errorf("originating identity value must be valid JSON: %v", err)
+	}
+	return &OriginatingIdentity{
+		Platform: platform,
+		Value:    string(decodedValue),
+	}, nil
+}
+
 func isValidJSON(s string) error {
 	var js json.RawMessage
 	return json.Unmarshal([]byte(s), &js)
@@ -306,6 +1049,59 @@ func (c *client) validateClientVersionIsAtLeast(version APIVersion) error {
 	return nil
 }
 
+// deleteRequestBody returns the body of a DELETE request: an empty JSON
+// object if sendEmptyBody is set, otherwise no body at all.
+func deleteRequestBody(sendEmptyBody bool) interface{} {
+	if sendEmptyBody {
+		return struct{}{}
+	}
+	return nil
+}
+
+// requestContext returns the context to send with a request having the given
+// Context: the client's DefaultContext merged with it, its keys winning.  The
+// maps are not modified.
+func (c *client) requestContext(requested map[string]interface{}) map[string]interface{} {
+	if len(c.DefaultContext) == 0 {
+		return requested
+	}
+
+	merged := make(map[string]interface{}, len(c.DefaultContext)+len(requested))
+	for k, v := range c.DefaultContext {
+		merged[k] = v
+	}
+	for k, v := range requested {
+		merged[k] = v
+	}
+	return merged
+}
+
+// acceptsIncomplete returns whether an instance request is sent with
+// accepts_incomplete=true: if the request asks for it or if the client
+// always accepts incomplete operations.
+func (c *client) acceptsIncomplete(requested bool) bool {
+	return requested || c.AlwaysAcceptIncomplete
+}
+
+// bindingAcceptsIncomplete is like acceptsIncomplete for binding requests,
+// which AlwaysAcceptIncomplete only applies to with API version >= 2.14, the
+// first one supporting asynchronous binding operations.
+func (c *client) bindingAcceptsIncomplete(requested bool) bool {
+	return requested || (c.AlwaysAcceptIncomplete && c.APIVersion.AtLeast(Version2_14()))
+}
+
+// setAcceptsIncomplete sets the accepts_incomplete query parameter in
+// params. The parameter is only sent when true, unless sendFalse asks for
+// an explicit false.
+func setAcceptsIncomplete(params map[string]string, acceptsIncomplete, sendFalse bool) {
+	switch {
+	case acceptsIncomplete:
+		params[AcceptsIncomplete] = "true"
+	case sendFalse:
+		params[AcceptsIncomplete] = "false"
+	}
+}
+
 // drainReader reads and discards the remaining data in reader (for example
 // response body data) For HTTP this ensures that the http connection
 // could be reused for another request if the keepalive is enabled.
@@ -323,5 +1119,59 @@ func drainReader(reader io.Reader) error {
 // internal message body types
 
 type asyncSuccessResponseBody struct {
-	Operation *string `json:"operation"`
+	asyncOperationFields
+}
+
+// asyncOperationFields holds the fields in which brokers return the key of
+// an asynchronous operation.  The spec-defined field is 'operation', holding
+// a string; the other forms are aliases used by brokers that predate the
+// finalized field name.
+type asyncOperationFields struct {
+	Operation    json.RawMessage `json:"operation,omitempty"`
+	OperationKey json.RawMessage `json:"operation_key,omitempty"`
+}
+
+// nestedOperationKeyField is the field holding the operation key when a
+// broker nests it in an 'operation' object.
+const nestedOperationKeyField = "key"
+
+// operationKey returns the operation key held in the given fields, or nil if
+// there is none.  The aliases 'operation_key' and an 'operation' object with
+// a 'key' field are only accepted if AcceptOperationKeyAliases is set, so
+// that they do not mask broker bugs otherwise.
+func (c *client) operationKey(fields asyncOperationFields) (*OperationKey, error) {
+	key, found, err := decodeOperationKey(fields.Operation)
+	if found || !c.AcceptOperationKeyAliases {
+		return key, err
+	}
+
+	var nested map[string]json.RawMessage
+	if json.Unmarshal(fields.Operation, &nested) == nil {
+		if key, found, _ := decodeOperationKey(nested[nestedOperationKeyField]); found {
+			return key, nil
+		}
+	}
+
+	if key, found, _ := decodeOperationKey(fields.OperationKey); found {
+		return key, nil
+	}
+
+	return nil, err
+}
+
+// decodeOperationKey decodes a raw JSON string into an operation key.  It
+// returns whether a key was found, and an error if the raw value is set but
+// is not a string.
+func decodeOperationKey(raw json.RawMessage) (*OperationKey, bool, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, false, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, false, err
+	}
+
+	key := OperationKey(s)
+	return &key, true, nil
 }
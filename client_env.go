@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Environment variables read by ConfigurationFromEnv.
+const (
+	// EnvBrokerURL is the URL of the broker.  It is required.
+	EnvBrokerURL = "OSB_BROKER_URL"
+	// EnvBrokerUsername is the basic auth username.
+	EnvBrokerUsername = "OSB_BROKER_USERNAME"
+	// EnvBrokerPassword is the basic auth password.
+	EnvBrokerPassword = "OSB_BROKER_PASSWORD"
+	// EnvBrokerToken is the bearer token.  It cannot be combined with basic
+	// auth.
+	EnvBrokerToken = "OSB_BROKER_TOKEN"
+	// EnvAPIVersion is the API version to use, such as "2.14".  The latest
+	// supported version is used if it is unset.
+	EnvAPIVersion = "OSB_API_VERSION"
+	// EnvInsecure is whether to skip TLS verification, in any format
+	// accepted by strconv.ParseBool.
+	EnvInsecure = "OSB_BROKER_INSECURE"
+	// EnvCAFile is the path to a PEM-encoded bundle of root certificates
+	// to verify the broker with.
+	EnvCAFile = "OSB_BROKER_CA_FILE"
+)
+
+// ConfigurationFromEnv returns the default client configuration, with the
+// settings given by the OSB_* environment variables above.  Basic auth is
+// used if OSB_BROKER_USERNAME is set, and bearer auth if OSB_BROKER_TOKEN
+// is set.
+func ConfigurationFromEnv() (*ClientConfiguration, error) {
+	config := DefaultClientConfiguration()
+
+	config.URL = os.Getenv(EnvBrokerURL)
+	if config.URL == "" {
+		return nil, fmt.Errorf("%s must be set", EnvBrokerURL)
+	}
+	config.Name = config.URL
+
+	if label := os.Getenv(EnvAPIVersion); label != "" {
+		version, ok := APIVersions()[label]
+		if !ok {
+			return nil, fmt.Errorf("%s: unsupported API version %q", EnvAPIVersion, label)
+		}
+		config.APIVersion = version
+	}
+
+	username, password := os.Getenv(EnvBrokerUsername), os.Getenv(EnvBrokerPassword)
+	token := os.Getenv(EnvBrokerToken)
+	if username != "" || password != "" || token != "" {
+		config.AuthConfig = &AuthConfig{}
+	}
+	if username != "" || password != "" {
+		config.AuthConfig.BasicAuthConfig = &BasicAuthConfig{
+			Username: username,
+			Password: password,
+		}
+	}
+	if token != "" {
+		config.AuthConfig.BearerConfig = &BearerConfig{
+			Token: token,
+		}
+	}
+	if err := validateAuthConfig(config.AuthConfig); err != nil {
+		return nil, err
+	}
+
+	if value := os.Getenv(EnvInsecure); value != "" {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", EnvInsecure, err)
+		}
+		config.Insecure = insecure
+	}
+
+	if path := os.Getenv(EnvCAFile); path != "" {
+		caData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", EnvCAFile, err)
+		}
+		config.CAData = caData
+	}
+
+	return config, nil
+}
+
+// NewClientFromEnv creates a new Client from the configuration returned by
+// ConfigurationFromEnv.
+func NewClientFromEnv() (Client, error) {
+	config, err := ConfigurationFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(config)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigurationFromEnv(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("ca-data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name               string
+		env                map[string]string
+		expectedAuth       *AuthConfig
+		expectedVersion    APIVersion
+		expectedInsecure   bool
+		expectedCAData     []byte
+		expectedErrMessage string
+	}{
+		{
+			name: "no auth",
+			env: map[string]string{
+				EnvBrokerURL: "https://broker.example.com",
+			},
+			expectedVersion: LatestAPIVersion(),
+		},
+		{
+			name: "basic auth",
+			env: map[string]string{
+				EnvBrokerURL:      "https://broker.example.com",
+				EnvBrokerUsername: "user",
+				EnvBrokerPassword: "pass",
+				EnvAPIVersion:     "2.14",
+			},
+			expectedAuth: &AuthConfig{
+				BasicAuthConfig: &BasicAuthConfig{Username: "user", Password: "pass"},
+			},
+			expectedVersion: Version2_14(),
+		},
+		{
+			name: "bearer auth",
+			env: map[string]string{
+				EnvBrokerURL:   "https://broker.example.com",
+				EnvBrokerToken: "token",
+				EnvInsecure:    "true",
+			},
+			expectedAuth: &AuthConfig{
+				BearerConfig: &BearerConfig{Token: "token"},
+			},
+			expectedVersion:  LatestAPIVersion(),
+			expectedInsecure: true,
+		},
+		{
+			name: "CA file",
+			env: map[string]string{
+				EnvBrokerURL: "https://broker.example.com",
+				EnvCAFile:    caFile,
+			},
+			expectedVersion: LatestAPIVersion(),
+			expectedCAData:  []byte("ca-data"),
+		},
+		{
+			name:               "missing URL",
+			env:                map[string]string{},
+			expectedErrMessage: "OSB_BROKER_URL must be set",
+		},
+		{
+			name: "basic and bearer auth",
+			env: map[string]string{
+				EnvBrokerURL:      "https://broker.example.com",
+				EnvBrokerUsername: "user",
+				EnvBrokerToken:    "token",
+			},
+			expectedErrMessage: "Only one AuthConfig implementation must be set at a time",
+		},
+		{
+			name: "unsupported API version",
+			env: map[string]string{
+				EnvBrokerURL:  "https://broker.example.com",
+				EnvAPIVersion: "3.0",
+			},
+			expectedErrMessage: `OSB_API_VERSION: unsupported API version "3.0"`,
+		},
+		{
+			name: "invalid insecure flag",
+			env: map[string]string{
+				EnvBrokerURL: "https://broker.example.com",
+				EnvInsecure:  "maybe",
+			},
+			expectedErrMessage: `OSB_BROKER_INSECURE: strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+	}
+
+	envVars := []string{EnvBrokerURL, EnvBrokerUsername, EnvBrokerPassword, EnvBrokerToken, EnvAPIVersion, EnvInsecure, EnvCAFile}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range envVars {
+				t.Setenv(name, tc.env[name])
+			}
+
+			config, err := ConfigurationFromEnv()
+			if tc.expectedErrMessage != "" {
+				if err == nil || err.Error() != tc.expectedErrMessage {
+					t.Fatalf("expected error %q, got %v", tc.expectedErrMessage, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if config.URL != tc.env[EnvBrokerURL] {
+				t.Errorf("unexpected URL: %q", config.URL)
+			}
+			if !reflect.DeepEqual(config.AuthConfig, tc.expectedAuth) {
+				t.Errorf("unexpected auth config: %+v", config.AuthConfig)
+			}
+			if config.APIVersion != tc.expectedVersion {
+				t.Errorf("unexpected API version: %v", config.APIVersion)
+			}
+			if config.Insecure != tc.expectedInsecure {
+				t.Errorf("unexpected insecure flag: %v", config.Insecure)
+			}
+			if !reflect.DeepEqual(config.CAData, tc.expectedCAData) {
+				t.Errorf("unexpected CA data: %q", config.CAData)
+			}
+		})
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvBrokerURL, "https://broker.example.com")
+	t.Setenv(EnvBrokerToken, "token")
+
+	klient, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c := klient.(*client); c.URL != "https://broker.example.com" || c.AuthConfig.BearerConfig.Token != "token" {
+		t.Errorf("unexpected client: %+v", c)
+	}
+}
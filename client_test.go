@@ -18,13 +18,26 @@ package v2
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"k8s.io/klog/v2"
 )
 
 const malformedResponse = `{`
@@ -173,6 +186,7 @@ func TestBuildOriginatingIdentityHeaderValue(t *testing.T) {
 		name                string
 		platform            string
 		value               string
+		maxBytes            int
 		expectedHeaderValue string
 		expectedError       bool
 	}{
@@ -200,13 +214,27 @@ func TestBuildOriginatingIdentityHeaderValue(t *testing.T) {
 			value:         "{\"user\":name}",
 			expectedError: true,
 		},
+		{
+			name:                "value within the size limit",
+			platform:            testOriginatingIdentityPlatform,
+			value:               testOriginatingIdentityValue,
+			maxBytes:            len(testOriginatingIdentityHeaderValue),
+			expectedHeaderValue: testOriginatingIdentityHeaderValue,
+		},
+		{
+			name:          "oversized value",
+			platform:      testOriginatingIdentityPlatform,
+			value:         `{"groups":["` + strings.Repeat("g", 8192) + `"]}`,
+			maxBytes:      defaultMaxOriginatingIdentityBytes,
+			expectedError: true,
+		},
 	}
 	for _, tc := range cases {
 		originatingIdentity := &OriginatingIdentity{
 			Platform: tc.platform,
 			Value:    tc.value,
 		}
-		headerValue, err := buildOriginatingIdentityHeaderValue(originatingIdentity)
+		headerValue, err := buildOriginatingIdentityHeaderValue(originatingIdentity, tc.maxBytes)
 		if e, a := tc.expectedError, err != nil; e != a {
 			if e {
 				t.Errorf("%v: expected error not found", tc.name)
@@ -221,6 +249,79 @@ func TestBuildOriginatingIdentityHeaderValue(t *testing.T) {
 	}
 }
 
+func TestParseOriginatingIdentityHeader(t *testing.T) {
+	cases := []struct {
+		name             string
+		headerValue      string
+		expectedIdentity *OriginatingIdentity
+		expectedError    bool
+	}{
+		{
+			name:             "valid header",
+			headerValue:      testOriginatingIdentityHeaderValue,
+			expectedIdentity: testOriginatingIdentity,
+		},
+		{
+			name:          "empty header",
+			headerValue:   "",
+			expectedError: true,
+		},
+		{
+			name:          "missing value",
+			headerValue:   testOriginatingIdentityPlatform,
+			expectedError: true,
+		},
+		{
+			name:          "empty platform",
+			headerValue:   " eyJ1c2VyIjoibmFtZSJ9",
+			expectedError: true,
+		},
+		{
+			name:          "empty value",
+			headerValue:   "fakeplatform ",
+			expectedError: true,
+		},
+		{
+			name:          "invalid base64",
+			headerValue:   "fakeplatform not-base64!",
+			expectedError: true,
+		},
+		{
+			name:          "invalid value json",
+			headerValue:   "fakeplatform " + base64.StdEncoding.EncodeToString([]byte("{\"user\":name}")),
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		identity, err := ParseOriginatingIdentityHeader(tc.headerValue)
+		if e, a := tc.expectedError, err != nil; e != a {
+			if e {
+				t.Errorf("%v: expected error not found", tc.name)
+			} else {
+				t.Errorf("%v: unexpected error: got %v", tc.name, err)
+			}
+			continue
+		}
+		if e, a := tc.expectedIdentity, identity; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected identity: expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
+
+func TestOriginatingIdentityHeaderRoundTrip(t *testing.T) {
+	headerValue, err := buildOriginatingIdentityHeaderValue(testOriginatingIdentity, 0)
+	if err != nil {
+		t.Fatalf("unexpected error building header: %v", err)
+	}
+	identity, err := ParseOriginatingIdentityHeader(headerValue)
+	if err != nil {
+		t.Fatalf("unexpected error parsing header: %v", err)
+	}
+	if e, a := testOriginatingIdentity, identity; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected identity: expected %+v, got %+v", e, a)
+	}
+}
+
 const justDescriptionErr = `{
   "description": "test description"
 }`
@@ -300,3 +401,1556 @@ func TestHandleFailureResponse(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalResponseIgnoresContentType(t *testing.T) {
+	cases := []struct {
+		name   string
+		header http.Header
+	}{
+		{
+			name: "no content type",
+		},
+		{
+			name:   "text/plain content type",
+			header: http.Header{contentType: []string{"text/plain"}},
+		},
+		{
+			name:   "application/json content type",
+			header: http.Header{contentType: []string{jsonType}},
+		},
+	}
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+
+		testResponse := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     tc.header,
+			Body:       closer(`{"status": "ok"}`),
+		}
+		statusResponse := &GetStatusResponse{}
+		if err := klient.unmarshalResponse(testResponse, statusResponse); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := "ok", statusResponse.Status; e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestHandleFailureResponseWithoutContentType(t *testing.T) {
+	klient := newTestClient(t, "failure without content type", Version2_11(), false, httpChecks{}, httpReaction{})
+
+	testResponse := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Body:       closer(conventionalFailureResponseBody),
+	}
+	err := klient.handleFailureResponse(testResponse)
+
+	if e, a := testHTTPStatusCodeError(), err; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected error:\n\nexpected: %+v\n\ngot:      %+v", e, a)
+	}
+}
+
+func fakeClock(now time.Time) func() time.Time {
+	return func() time.Time {
+		return now
+	}
+}
+
+func TestPollDelay(t *testing.T) {
+	now := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		value    string
+		expected *time.Duration
+	}{
+		{
+			name: "no header",
+		},
+		{
+			name:     "seconds",
+			value:    "30",
+			expected: durationPtr(30 * time.Second),
+		},
+		{
+			name:     "fractional seconds",
+			value:    "1.5",
+			expected: durationPtr(1500 * time.Millisecond),
+		},
+		{
+			name:  "zero seconds",
+			value: "0",
+		},
+		{
+			name:  "not a number",
+			value: "NaN",
+		},
+		{
+			name:  "infinite seconds",
+			value: "+Inf",
+		},
+		{
+			name:  "seconds overflowing a duration",
+			value: "1e20",
+		},
+		{
+			name:  "negative seconds",
+			value: "-5",
+		},
+		{
+			name:     "http date in the future",
+			value:    now.Add(90 * time.Second).Format(http.TimeFormat),
+			expected: durationPtr(90 * time.Second),
+		},
+		{
+			name:     "http date in the past",
+			value:    now.Add(-90 * time.Second).Format(http.TimeFormat),
+			expected: durationPtr(0),
+		},
+		{
+			name:  "RFC 3339 timestamp",
+			value: "2021-01-23T23:12:00Z",
+		},
+	}
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		klient.clock = fakeClock(now)
+
+		header := http.Header{}
+		if tc.value != "" {
+			header.Set(PollingDelayHeader, tc.value)
+		}
+
+		delay := klient.pollDelay(&http.Response{Header: header})
+		if e, a := tc.expected, delay; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	date := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+
+	cases := []struct {
+		name          string
+		header        http.Header
+		expected      time.Duration
+		expectedValid bool
+	}{
+		{
+			name:   "no header",
+			header: http.Header{},
+		},
+		{
+			name:          "seconds",
+			header:        http.Header{PollingDelayHeader: {"30"}},
+			expected:      30 * time.Second,
+			expectedValid: true,
+		},
+		{
+			name:          "fractional seconds",
+			header:        http.Header{PollingDelayHeader: {"0.25"}},
+			expected:      250 * time.Millisecond,
+			expectedValid: true,
+		},
+		{
+			name:   "zero seconds",
+			header: http.Header{PollingDelayHeader: {"0"}},
+		},
+		{
+			name: "http date relative to date header",
+			header: http.Header{
+				PollingDelayHeader: {date.Add(2 * time.Minute).Format(http.TimeFormat)},
+				dateHeader:         {date.Format(http.TimeFormat)},
+			},
+			expected:      2 * time.Minute,
+			expectedValid: true,
+		},
+		{
+			name: "past http date",
+			header: http.Header{
+				PollingDelayHeader: {date.Add(-2 * time.Minute).Format(http.TimeFormat)},
+				dateHeader:         {date.Format(http.TimeFormat)},
+			},
+			expected:      0,
+			expectedValid: true,
+		},
+		{
+			name:   "invalid value",
+			header: http.Header{PollingDelayHeader: {"soon"}},
+		},
+	}
+
+	for _, tc := range cases {
+		delay, ok := ParseRetryAfter(&http.Response{Header: tc.header})
+		if e, a := tc.expectedValid, ok; e != a {
+			t.Errorf("%v: expected valid to be %v, got %v", tc.name, e, a)
+		}
+		if e, a := tc.expected, delay; e != a {
+			t.Errorf("%v: expected delay %v, got %v", tc.name, e, a)
+		}
+	}
+
+	// Without a Date header, HTTP dates are relative to the current time.
+	retryAt := time.Now().Add(time.Hour)
+	delay, ok := ParseRetryAfter(&http.Response{Header: http.Header{PollingDelayHeader: {retryAt.Format(http.TimeFormat)}}})
+	if !ok || delay <= 58*time.Minute || delay > time.Hour {
+		t.Errorf("expected a delay of about an hour, got %v, %v", delay, ok)
+	}
+}
+
+func TestVerifyRequestIdentity(t *testing.T) {
+	const otherRequestIdentity = "3b8a8f4e-0c1e-4b1a-9a55-2f4a9d0b6c21"
+
+	cases := []struct {
+		name         string
+		verification RequestIdentityVerification
+		echo         func(sent string) string
+		expectedErr  bool
+	}{
+		{
+			name:         "mismatch without verification",
+			verification: RequestIdentityVerificationNone,
+			echo:         func(string) string { return otherRequestIdentity },
+		},
+		{
+			name:         "mismatch with warning",
+			verification: RequestIdentityVerificationWarn,
+			echo:         func(string) string { return otherRequestIdentity },
+		},
+		{
+			name:         "mismatch with strict verification",
+			verification: RequestIdentityVerificationStrict,
+			echo:         func(string) string { return otherRequestIdentity },
+			expectedErr:  true,
+		},
+		{
+			name:         "match with strict verification",
+			verification: RequestIdentityVerificationStrict,
+			echo:         func(sent string) string { return sent },
+		},
+		{
+			name:         "no echo with strict verification",
+			verification: RequestIdentityVerificationStrict,
+			echo:         func(string) string { return "" },
+		},
+	}
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		klient.RequestIdentityVerification = tc.verification
+
+		var sent string
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			sent = request.Header.Get(RequestIdentityheader)
+			header := http.Header{}
+			if echoed := tc.echo(sent); echoed != "" {
+				header.Set(RequestIdentityheader, echoed)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       closer(`{"status": "ok"}`),
+			}, nil
+		}
+
+		_, err := klient.GetStatus()
+		if !tc.expectedErr {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+
+		mismatchErr, ok := err.(RequestIdentityMismatchError)
+		if !ok {
+			t.Errorf("%v: expected RequestIdentityMismatchError, got %v", tc.name, err)
+			continue
+		}
+		expectedMeta := ResponseMeta{
+			SentRequestIdentity:     sent,
+			ReceivedRequestIdentity: otherRequestIdentity,
+		}
+		if e, a := expectedMeta, mismatchErr.Meta; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
+
+func TestOperationKey(t *testing.T) {
+	cases := []struct {
+		name          string
+		body          string
+		acceptAliases bool
+		expected      *OperationKey
+		expectedErr   bool
+	}{
+		{
+			name: "no operation",
+			body: `{}`,
+		},
+		{
+			name:     "operation",
+			body:     `{"operation": "test-operation-key"}`,
+			expected: &testOperation,
+		},
+		{
+			name: "operation_key alias ignored by default",
+			body: `{"operation_key": "test-operation-key"}`,
+		},
+		{
+			name:          "operation_key alias",
+			body:          `{"operation_key": "test-operation-key"}`,
+			acceptAliases: true,
+			expected:      &testOperation,
+		},
+		{
+			name:        "nested operation rejected by default",
+			body:        `{"operation": {"key": "test-operation-key"}}`,
+			expectedErr: true,
+		},
+		{
+			name:          "nested operation alias",
+			body:          `{"operation": {"key": "test-operation-key"}}`,
+			acceptAliases: true,
+			expected:      &testOperation,
+		},
+		{
+			name:          "operation takes precedence over aliases",
+			body:          `{"operation": "test-operation-key", "operation_key": "other"}`,
+			acceptAliases: true,
+			expected:      &testOperation,
+		},
+		{
+			name:          "unrecognized operation object",
+			body:          `{"operation": {"id": "test-operation-key"}}`,
+			acceptAliases: true,
+			expectedErr:   true,
+		},
+	}
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		klient.AcceptOperationKeyAliases = tc.acceptAliases
+
+		body := &asyncSuccessResponseBody{}
+		if err := klient.unmarshalResponse(&http.Response{Body: closer(tc.body)}, body); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		key, err := klient.operationKey(body.asyncOperationFields)
+		if e, a := tc.expectedErr, err != nil; e != a {
+			t.Errorf("%v: expected error %v, got %v", tc.name, e, err)
+		}
+		if e, a := tc.expected, key; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestPollDelayWithDateHeader(t *testing.T) {
+	now := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+	retryAt := now.Add(2 * time.Minute)
+
+	cases := []struct {
+		name         string
+		date         string
+		maxClockSkew time.Duration
+		expected     *time.Duration
+	}{
+		{
+			name:     "date matches clock",
+			date:     now.Format(http.TimeFormat),
+			expected: durationPtr(2 * time.Minute),
+		},
+		{
+			name:     "broker clock ahead",
+			date:     now.Add(time.Minute).Format(http.TimeFormat),
+			expected: durationPtr(time.Minute),
+		},
+		{
+			name:     "broker clock behind",
+			date:     now.Add(-time.Hour).Format(http.TimeFormat),
+			expected: durationPtr(time.Hour + 2*time.Minute),
+		},
+		{
+			name:         "broker clock behind clamped",
+			date:         now.Add(-time.Hour).Format(http.TimeFormat),
+			maxClockSkew: 5 * time.Minute,
+			expected:     durationPtr(7 * time.Minute),
+		},
+		{
+			name:         "broker clock ahead clamped",
+			date:         now.Add(time.Hour).Format(http.TimeFormat),
+			maxClockSkew: 30 * time.Second,
+			expected:     durationPtr(90 * time.Second),
+		},
+		{
+			name:         "skew within tolerance",
+			date:         now.Add(time.Minute).Format(http.TimeFormat),
+			maxClockSkew: 5 * time.Minute,
+			expected:     durationPtr(time.Minute),
+		},
+		{
+			name:     "invalid date falls back to clock",
+			date:     "yesterday",
+			expected: durationPtr(2 * time.Minute),
+		},
+	}
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		klient.clock = fakeClock(now)
+		klient.MaxClockSkew = tc.maxClockSkew
+
+		header := http.Header{}
+		header.Set(PollingDelayHeader, retryAt.Format(http.TimeFormat))
+		header.Set(dateHeader, tc.date)
+
+		delay := klient.pollDelay(&http.Response{Header: header})
+		if e, a := tc.expected, delay; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestUnmarshalResponseRejectsTrailingData(t *testing.T) {
+	cases := []struct {
+		name  string
+		body  string
+		valid bool
+	}{
+		{
+			name:  "single value",
+			body:  `{"status": "ok"}`,
+			valid: true,
+		},
+		{
+			name:  "single value with trailing whitespace",
+			body:  "{\"status\": \"ok\"}\n\n",
+			valid: true,
+		},
+		{
+			name: "two concatenated objects",
+			body: `{"status": "ok"}{"status": "ok"}`,
+		},
+		{
+			name: "trailing garbage",
+			body: `{"status": "ok"} garbage`,
+		},
+	}
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+
+		testResponse := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       closer(tc.body),
+		}
+		err := klient.unmarshalResponse(testResponse, &GetStatusResponse{})
+		if e, a := tc.valid, err == nil; e != a {
+			t.Errorf("%v: expected valid %v, got error %v", tc.name, e, err)
+		}
+	}
+}
+
+func TestSetAcceptsIncomplete(t *testing.T) {
+	cases := []struct {
+		name              string
+		acceptsIncomplete bool
+		sendFalse         bool
+		expected          map[string]string
+	}{
+		{
+			name:     "omitted by default",
+			expected: map[string]string{},
+		},
+		{
+			name:              "true",
+			acceptsIncomplete: true,
+			expected:          map[string]string{AcceptsIncomplete: "true"},
+		},
+		{
+			name:              "true wins over explicit false",
+			acceptsIncomplete: true,
+			sendFalse:         true,
+			expected:          map[string]string{AcceptsIncomplete: "true"},
+		},
+		{
+			name:      "explicit false",
+			sendFalse: true,
+			expected:  map[string]string{AcceptsIncomplete: "false"},
+		},
+	}
+
+	for _, tc := range cases {
+		params := map[string]string{}
+		setAcceptsIncomplete(params, tc.acceptsIncomplete, tc.sendFalse)
+		if !reflect.DeepEqual(tc.expected, params) {
+			t.Errorf("%v: unexpected params; expected %v, got %v", tc.name, tc.expected, params)
+		}
+	}
+}
+
+func TestTruncateForLog(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		max      int
+		expected string
+	}{
+		{
+			name:     "no limit",
+			body:     "0123456789",
+			expected: "0123456789",
+		},
+		{
+			name:     "shorter than limit",
+			body:     "0123456789",
+			max:      20,
+			expected: "0123456789",
+		},
+		{
+			name:     "exactly the limit",
+			body:     "0123456789",
+			max:      10,
+			expected: "0123456789",
+		},
+		{
+			name:     "longer than limit",
+			body:     "0123456789",
+			max:      4,
+			expected: "0123... (10 bytes total)",
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, truncateForLog([]byte(tc.body), tc.max); e != a {
+			t.Errorf("%v: expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestUnmarshalResponseWithTruncatedLog(t *testing.T) {
+	klient := newTestClient(t, "truncated log", Version2_11(), false, httpChecks{}, httpReaction{})
+	klient.MaxLogBodyBytes = 8
+
+	response := &http.Response{Body: closer(okCatalogBytes)}
+	catalog := &CatalogResponse{}
+	if err := klient.unmarshalResponse(response, catalog); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := okCatalogResponse(), catalog; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the full body to be unmarshaled; expected %+v, got %+v", e, a)
+	}
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	httpChecks := httpChecks{
+		body:    defaultRotateBindingRequestBody,
+		headers: map[string]string{APIVersionHeader: Version2_17().HeaderValue()},
+	}
+	httpReaction := httpReaction{
+		status: http.StatusCreated,
+		body:   successRotateBindingResponseBody,
+	}
+	original := newTestClient(t, "with API version", Version2_16(), false, httpChecks, httpReaction)
+
+	derived := WithAPIVersion(original, Version2_17())
+
+	if _, err := original.RotateBinding(defaultRotateBindingRequest()); err == nil {
+		t.Error("expected RotateBinding to be refused by the original client")
+	}
+
+	response, err := derived.RotateBinding(defaultRotateBindingRequest())
+	if err != nil {
+		t.Fatalf("unexpected error from the derived client: %v", err)
+	}
+	if e, a := successRotatebindingResponse(), response; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected response; expected %+v, got %+v", e, a)
+	}
+
+	if e, a := Version2_16(), original.BrokerAPIVersion(); e != a {
+		t.Errorf("expected the original client to be unchanged; expected %v, got %v", e, a)
+	}
+	if e, a := Version2_17(), derived.BrokerAPIVersion(); e != a {
+		t.Errorf("unexpected derived API version; expected %v, got %v", e, a)
+	}
+}
+
+func TestWithAPIVersionSharesCatalog(t *testing.T) {
+	original := newTestClient(t, "shared catalog", Version2_13(), false, httpChecks{}, httpReaction{
+		status: http.StatusOK,
+		body:   schemaCatalogBytes,
+	})
+	original.ValidateParametersAgainstSchemas = true
+
+	derived := WithAPIVersion(original, Version2_14()).(*client)
+	if _, err := derived.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan := schemaCatalogResponse().Services[0].Plans[0]
+	if original.planSchemas(schemaCatalogResponse().Services[0].ID, plan.ID) == nil {
+		t.Error("expected the catalog fetched by the derived client to be shared with the original")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	var (
+		lock          sync.Mutex
+		closeOnce     sync.Once
+		closed        = make(chan struct{})
+		authorization []string
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		lock.Unlock()
+		w.Write([]byte(`{"services": []}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closeOnce.Do(func() { close(closed) })
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	config := DefaultClientConfiguration()
+	config.URL = server.URL
+	config.AuthConfig = &AuthConfig{BearerConfig: &BearerConfig{Token: "old-token"}}
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	if _, err := c.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error getting catalog: %v", err)
+	}
+
+	invalid := DefaultClientConfiguration()
+	invalid.AuthConfig = &AuthConfig{}
+	if err := c.(Refresher).Refresh(invalid); err == nil {
+		t.Fatal("expected error refreshing with an invalid configuration")
+	}
+
+	refreshed := DefaultClientConfiguration()
+	refreshed.URL = "https://ignored.example.com"
+	refreshed.AuthConfig = &AuthConfig{BearerConfig: &BearerConfig{Token: "new-token"}}
+	if err := c.(Refresher).Refresh(refreshed); err != nil {
+		t.Fatalf("unexpected error refreshing client: %v", err)
+	}
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the idle connection of the previous transport to be closed")
+	}
+
+	if e, a := server.URL, c.BrokerURL(); e != a {
+		t.Errorf("expected URL to be left unchanged; expected %v, got %v", e, a)
+	}
+
+	if _, err := c.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error getting catalog after refresh: %v", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if e, a := []string{"Bearer old-token", "Bearer new-token"}, authorization; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected authorization headers; expected %v, got %v", e, a)
+	}
+}
+
+func TestRefreshDerivedClients(t *testing.T) {
+	var (
+		lock          sync.Mutex
+		authorization []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		lock.Unlock()
+		w.Write([]byte(`{"services": []}`))
+	}))
+	defer server.Close()
+
+	config := DefaultClientConfiguration()
+	config.URL = server.URL
+	config.AuthConfig = &AuthConfig{BearerConfig: &BearerConfig{Token: "old-token"}}
+	original, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	derived := WithAPIVersion(original, Version2_14())
+
+	refreshed := DefaultClientConfiguration()
+	refreshed.AuthConfig = &AuthConfig{BearerConfig: &BearerConfig{Token: "new-token"}}
+	if err := original.(Refresher).Refresh(refreshed); err != nil {
+		t.Fatalf("unexpected error refreshing client: %v", err)
+	}
+	if _, err := derived.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error getting catalog from the derived client: %v", err)
+	}
+
+	refreshed.AuthConfig = &AuthConfig{BearerConfig: &BearerConfig{Token: "newer-token"}}
+	if err := derived.(Refresher).Refresh(refreshed); err != nil {
+		t.Fatalf("unexpected error refreshing derived client: %v", err)
+	}
+	if _, err := original.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error getting catalog from the original client: %v", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if e, a := []string{"Bearer new-token", "Bearer newer-token"}, authorization; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected authorization headers; expected %v, got %v", e, a)
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	var signed string
+	klient := newTestClient(t, "sign request", Version2_13(), false, httpChecks{
+		body:    successProvisionRequestBody,
+		params:  map[string]string{AcceptsIncomplete: "true"},
+		headers: map[string]string{"X-Signature": "signed"},
+	}, httpReaction{
+		status: http.StatusCreated,
+		body:   successProvisionResponseBody,
+	})
+	klient.AuthConfig = &AuthConfig{BearerConfig: &BearerConfig{Token: "token"}}
+	klient.SignRequest = func(request *http.Request) error {
+		body, err := request.GetBody()
+		if err != nil {
+			return err
+		}
+		bodyBytes, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		signed = fmt.Sprintf("%s %s %s %s %s", request.Method, request.URL, request.Header.Get(APIVersionHeader), request.Header.Get("Authorization"), bodyBytes)
+		request.Header.Set("X-Signature", "signed")
+		return nil
+	}
+
+	if _, err := klient.ProvisionInstance(defaultAsyncProvisionRequest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := fmt.Sprintf("PUT https://example.com/v2/service_instances/test-instance-id?accepts_incomplete=true 2.13 Bearer token %s", successProvisionRequestBody)
+	if e, a := expected, signed; e != a {
+		t.Errorf("unexpected signed request; expected %q, got %q", e, a)
+	}
+
+	signErr := errors.New("signing failed")
+	klient.SignRequest = func(*http.Request) error {
+		return signErr
+	}
+	klient.doRequestFunc = func(*http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request sent after signing failed")
+		return nil, nil
+	}
+	if _, err := klient.ProvisionInstance(defaultProvisionRequest()); err != signErr {
+		t.Errorf("expected signing error, got %v", err)
+	}
+}
+
+func TestAlwaysAcceptIncomplete(t *testing.T) {
+	cases := []struct {
+		name     string
+		version  APIVersion
+		call     func(Client) error
+		expected string
+	}{
+		{
+			name:     "provision",
+			version:  Version2_13(),
+			call:     func(c Client) error { _, err := c.ProvisionInstance(defaultProvisionRequest()); return err },
+			expected: "true",
+		},
+		{
+			name:     "update",
+			version:  Version2_13(),
+			call:     func(c Client) error { _, err := c.UpdateInstance(defaultUpdateInstanceRequest()); return err },
+			expected: "true",
+		},
+		{
+			name:     "deprovision",
+			version:  Version2_13(),
+			call:     func(c Client) error { _, err := c.DeprovisionInstance(defaultDeprovisionRequest()); return err },
+			expected: "true",
+		},
+		{
+			name:     "bind",
+			version:  Version2_14(),
+			call:     func(c Client) error { _, err := c.Bind(defaultBindRequest()); return err },
+			expected: "true",
+		},
+		{
+			name:     "unbind",
+			version:  Version2_14(),
+			call:     func(c Client) error { _, err := c.Unbind(defaultUnbindRequest()); return err },
+			expected: "true",
+		},
+		{
+			name:     "rotate binding",
+			version:  Version2_17(),
+			call:     func(c Client) error { _, err := c.RotateBinding(defaultRotateBindingRequest()); return err },
+			expected: "true",
+		},
+		{
+			name:     "bind before 2.14",
+			version:  Version2_13(),
+			call:     func(c Client) error { _, err := c.Bind(defaultBindRequest()); return err },
+			expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		var sent string
+		klient := newTestClient(t, tc.name, tc.version, false, httpChecks{}, httpReaction{})
+		klient.AlwaysAcceptIncomplete = true
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			sent = request.URL.Query().Get(AcceptsIncomplete)
+			return &http.Response{
+				StatusCode: http.StatusAccepted,
+				Body:       closer(`{}`),
+			}, nil
+		}
+
+		err := tc.call(klient)
+		if e, a := tc.expected, sent; e != a {
+			t.Errorf("%v: unexpected %v parameter; expected %q, got %q", tc.name, AcceptsIncomplete, e, a)
+		}
+		if tc.expected != "" && err != nil {
+			t.Errorf("%v: expected the asynchronous response to be accepted, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestUseJSONNumbers(t *testing.T) {
+	const largeID = "9007199254740993"
+	body := `{"credentials":{"id":` + largeID + `,"port":5432}}`
+
+	klient := newTestClient(t, "use JSON numbers", Version2_13(), false, httpChecks{
+		body: defaultBindRequestBody,
+	}, httpReaction{
+		status: http.StatusCreated,
+		body:   body,
+	})
+	klient.UseJSONNumbers = true
+
+	response, err := klient.Bind(defaultBindRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id, ok := response.Credentials["id"].(json.Number)
+	if !ok {
+		t.Fatalf("expected a json.Number, got %T", response.Credentials["id"])
+	}
+	if e, a := largeID, id.String(); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if port, err := response.Credentials["port"].(json.Number).Int64(); err != nil || port != 5432 {
+		t.Errorf("expected port 5432, got %v (%v)", port, err)
+	}
+
+	klient.UseJSONNumbers = false
+	response, err = klient.Bind(defaultBindRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := response.Credentials["id"].(float64); !ok {
+		t.Errorf("expected a float64 by default, got %T", response.Credentials["id"])
+	}
+}
+
+func TestUnmarshalJSONTrailingData(t *testing.T) {
+	for _, useNumber := range []bool{false, true} {
+		var obj map[string]interface{}
+		if err := unmarshalJSON([]byte(`{"a":1} {}`), &obj, useNumber); err == nil {
+			t.Errorf("useNumber %v: expected an error for trailing data", useNumber)
+		}
+		if err := unmarshalJSON([]byte(" {\"a\":1}\n"), &obj, useNumber); err != nil {
+			t.Errorf("useNumber %v: unexpected error: %v", useNumber, err)
+		}
+	}
+}
+
+func TestDefaultContext(t *testing.T) {
+	defaultContext := map[string]interface{}{
+		"platform":          "cloudfoundry",
+		"organization_guid": "default-org",
+	}
+
+	cases := []struct {
+		name         string
+		version      APIVersion
+		call         func(Client) error
+		expectedBody string
+	}{
+		{
+			name:    "provision without context",
+			version: Version2_13(),
+			call: func(c Client) error {
+				_, err := c.ProvisionInstance(defaultProvisionRequest())
+				return err
+			},
+			expectedBody: `{"service_id":"test-service-id","plan_id":"test-plan-id","organization_guid":"test-organization-guid","space_guid":"test-space-guid","context":{"organization_guid":"default-org","platform":"cloudfoundry"}}`,
+		},
+		{
+			name:    "provision with context",
+			version: Version2_13(),
+			call: func(c Client) error {
+				r := defaultProvisionRequest()
+				r.Context = map[string]interface{}{"platform": "kubernetes", "namespace": "test"}
+				_, err := c.ProvisionInstance(r)
+				return err
+			},
+			expectedBody: `{"service_id":"test-service-id","plan_id":"test-plan-id","organization_guid":"test-organization-guid","space_guid":"test-space-guid","context":{"namespace":"test","organization_guid":"default-org","platform":"kubernetes"}}`,
+		},
+		{
+			name:    "update without context",
+			version: Version2_13(),
+			call: func(c Client) error {
+				_, err := c.UpdateInstance(defaultUpdateInstanceRequest())
+				return err
+			},
+			expectedBody: `{"service_id":"test-service-id","plan_id":"test-plan-id","context":{"organization_guid":"default-org","platform":"cloudfoundry"}}`,
+		},
+		{
+			name:    "bind without context",
+			version: Version2_13(),
+			call: func(c Client) error {
+				_, err := c.Bind(defaultBindRequest())
+				return err
+			},
+			expectedBody: `{"service_id":"test-service-id","plan_id":"test-plan-id","context":{"organization_guid":"default-org","platform":"cloudfoundry"}}`,
+		},
+		{
+			name:    "bind before 2.13",
+			version: Version2_12(),
+			call: func(c Client) error {
+				_, err := c.Bind(defaultBindRequest())
+				return err
+			},
+			expectedBody: defaultBindRequestBody,
+		},
+	}
+
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, tc.version, false, httpChecks{
+			body: tc.expectedBody,
+		}, httpReaction{
+			status: http.StatusOK,
+			body:   `{}`,
+		})
+		klient.DefaultContext = defaultContext
+
+		if err := tc.call(klient); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+	}
+
+	if e, a := 2, len(defaultContext); e != a {
+		t.Errorf("expected the default context to be left unmodified, got %v", defaultContext)
+	}
+}
+
+func TestValidateTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	server.Close()
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	cases := []struct {
+		name     string
+		config   *ClientConfiguration
+		expected []string
+	}{
+		{
+			name:   "default",
+			config: &ClientConfiguration{},
+		},
+		{
+			name: "valid",
+			config: &ClientConfiguration{
+				CAData: caData,
+				TLSConfig: &tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					MaxVersion:   tls.VersionTLS13,
+					Certificates: []tls.Certificate{{Certificate: [][]byte{{1}}, PrivateKey: struct{}{}}},
+				},
+			},
+		},
+		{
+			name:     "insecure with CA data",
+			config:   &ClientConfiguration{Insecure: true, CAData: caData},
+			expected: []string{"Cannot specify root CAs and to skip TLS verification"},
+		},
+		{
+			name: "insecure with pinned root CAs",
+			config: &ClientConfiguration{TLSConfig: &tls.Config{
+				InsecureSkipVerify: true,
+				RootCAs:            x509.NewCertPool(),
+			}},
+			expected: []string{"Cannot specify root CAs and to skip TLS verification"},
+		},
+		{
+			name:     "invalid CA data",
+			config:   &ClientConfiguration{CAData: []byte("not a certificate")},
+			expected: []string{"CAData holds no PEM-encoded certificate"},
+		},
+		{
+			name: "minimum version above maximum",
+			config: &ClientConfiguration{TLSConfig: &tls.Config{
+				MinVersion: tls.VersionTLS13,
+				MaxVersion: tls.VersionTLS12,
+			}},
+			expected: []string{"TLS minimum version TLS 1.3 is above maximum version TLS 1.2"},
+		},
+		{
+			name: "client key without certificate",
+			config: &ClientConfiguration{TLSConfig: &tls.Config{
+				Certificates: []tls.Certificate{{PrivateKey: struct{}{}}},
+			}},
+			expected: []string{"client certificate 0 has a private key but no certificate"},
+		},
+		{
+			name: "client certificate without key",
+			config: &ClientConfiguration{TLSConfig: &tls.Config{
+				Certificates: []tls.Certificate{{Certificate: [][]byte{{1}}}},
+			}},
+			expected: []string{"client certificate 0 has no private key"},
+		},
+		{
+			name: "empty client certificate",
+			config: &ClientConfiguration{TLSConfig: &tls.Config{
+				Certificates: []tls.Certificate{{}},
+			}},
+			expected: []string{"client certificate 0 is empty"},
+		},
+		{
+			name:     "invalid renegotiation",
+			config:   &ClientConfiguration{TLSRenegotiation: tls.RenegotiationSupport(42)},
+			expected: []string{"invalid TLS renegotiation support 42"},
+		},
+		{
+			name: "several inconsistencies",
+			config: &ClientConfiguration{
+				Insecure: true,
+				CAData:   []byte("not a certificate"),
+				TLSConfig: &tls.Config{
+					MinVersion: tls.VersionTLS13,
+					MaxVersion: tls.VersionTLS12,
+				},
+			},
+			expected: []string{
+				"Cannot specify root CAs and to skip TLS verification",
+				"CAData holds no PEM-encoded certificate",
+				"TLS minimum version TLS 1.3 is above maximum version TLS 1.2",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc.config.URL = "https://example.com"
+		_, err := NewClient(tc.config)
+		if len(tc.expected) == 0 {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%v: expected an error", tc.name)
+			continue
+		}
+		if e, a := strings.Join(tc.expected, "\n"), err.Error(); e != a {
+			t.Errorf("%v: unexpected error; expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestTransformRequestBody(t *testing.T) {
+	wrapped := `{"envelope":` + successProvisionRequestBody + `}`
+	klient := newTestClient(t, "transform request body", Version2_13(), false, httpChecks{
+		body: wrapped,
+	}, httpReaction{
+		status: http.StatusCreated,
+		body:   successProvisionResponseBody,
+	})
+
+	var operations []string
+	klient.TransformRequestBody = func(operation string, body []byte) ([]byte, error) {
+		operations = append(operations, operation)
+		return []byte(`{"envelope":` + string(body) + `}`), nil
+	}
+
+	if _, err := klient.ProvisionInstance(defaultProvisionRequest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []string{"ProvisionInstance"}, operations; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected operations; expected %v, got %v", e, a)
+	}
+
+	transformErr := errors.New("transform failed")
+	klient.TransformRequestBody = func(string, []byte) ([]byte, error) {
+		return nil, transformErr
+	}
+	klient.doRequestFunc = func(*http.Request) (*http.Response, error) {
+		t.Fatal("unexpected request sent after the transform failed")
+		return nil, nil
+	}
+	if _, err := klient.ProvisionInstance(defaultProvisionRequest()); err != transformErr {
+		t.Errorf("expected transform error, got %v", err)
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	const header = "X-Correlation-ID"
+
+	cases := []struct {
+		name          string
+		header        string
+		correlationID func(*http.Request) string
+		expected      string
+	}{
+		{
+			name:          "configured",
+			header:        header,
+			correlationID: func(*http.Request) string { return "trace-id" },
+			expected:      "trace-id",
+		},
+		{
+			name:          "empty value",
+			header:        header,
+			correlationID: func(*http.Request) string { return "" },
+		},
+		{
+			name:   "no function",
+			header: header,
+		},
+		{
+			name:          "no header name",
+			correlationID: func(*http.Request) string { return "trace-id" },
+		},
+	}
+
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_13(), false, httpChecks{
+			headers: map[string]string{header: tc.expected},
+		}, httpReaction{
+			status: http.StatusOK,
+			body:   `{}`,
+		})
+		klient.CorrelationIDHeader = tc.header
+		klient.CorrelationID = tc.correlationID
+
+		if _, err := klient.GetCatalog(); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+	}
+}
+
+func TestSlowRequestThreshold(t *testing.T) {
+	var logs bytes.Buffer
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	if err := flags.Set("logtostderr", "false"); err != nil {
+		t.Fatal(err)
+	}
+	klog.SetOutput(&logs)
+	defer flags.Set("logtostderr", "true")
+
+	cases := []struct {
+		name      string
+		threshold time.Duration
+		delay     time.Duration
+		expected  bool
+	}{
+		{
+			name:      "slow request",
+			threshold: 10 * time.Millisecond,
+			delay:     50 * time.Millisecond,
+			expected:  true,
+		},
+		{
+			name:      "fast request",
+			threshold: time.Second,
+		},
+		{
+			name:  "threshold disabled",
+			delay: 50 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range cases {
+		logs.Reset()
+
+		klient := newTestClient(t, tc.name, LatestAPIVersion(), false, httpChecks{}, httpReaction{
+			status: http.StatusOK,
+			body:   okCatalogBytes,
+		})
+		klient.SlowRequestThreshold = tc.threshold
+		now := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+		klient.clock = func() time.Time {
+			return now
+		}
+		doRequest := klient.doRequestFunc
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			now = now.Add(tc.delay)
+			return doRequest(request)
+		}
+
+		if _, err := klient.GetCatalog(); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		klog.Flush()
+
+		if e, a := tc.expected, strings.Contains(logs.String(), "slow GetCatalog: GET request"); e != a {
+			t.Errorf("%v: expected warning %v, got logs %q", tc.name, e, logs.String())
+		}
+	}
+}
+
+// clockAdvancingBody moves a fake clock forward by delay on its first read.
+type clockAdvancingBody struct {
+	io.Reader
+	advance func()
+}
+
+func (b *clockAdvancingBody) Read(p []byte) (int, error) {
+	if b.advance != nil {
+		b.advance()
+		b.advance = nil
+	}
+	return b.Reader.Read(p)
+}
+
+func (b *clockAdvancingBody) Close() error {
+	return nil
+}
+
+func TestVerbosePhaseTimings(t *testing.T) {
+	var logs bytes.Buffer
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	if err := flags.Set("logtostderr", "false"); err != nil {
+		t.Fatal(err)
+	}
+	klog.SetOutput(&logs)
+	defer flags.Set("logtostderr", "true")
+
+	for _, verbose := range []bool{false, true} {
+		logs.Reset()
+
+		now := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+		klient := newTestClient(t, "phase timings", LatestAPIVersion(), false, httpChecks{}, httpReaction{})
+		klient.Verbose = verbose
+		klient.clock = func() time.Time {
+			return now
+		}
+		klient.doRequestFunc = func(*http.Request) (*http.Response, error) {
+			now = now.Add(20 * time.Millisecond)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: &clockAdvancingBody{
+					Reader:  strings.NewReader(okCatalogBytes),
+					advance: func() { now = now.Add(30 * time.Millisecond) },
+				},
+			}, nil
+		}
+
+		if _, err := klient.GetCatalog(); err != nil {
+			t.Fatalf("verbose %v: unexpected error: %v", verbose, err)
+		}
+		klog.Flush()
+
+		for _, expected := range []string{
+			`round trip to "https://example.com/v2/catalog" took 20ms`,
+			"reading response body took 30ms, unmarshalling took 0s",
+		} {
+			if e, a := verbose, strings.Contains(logs.String(), expected); e != a {
+				t.Errorf("verbose %v: expected %q logged to be %v, logs: %q", verbose, expected, e, logs.String())
+			}
+		}
+	}
+}
+
+func TestErrorBodyExcerpt(t *testing.T) {
+	malformed := `{"credentials":{"password":"s3cr3t","api_key":"k\"ey","uri":"postgres://user:pass@db:5432/x","port":5432`
+
+	cases := []struct {
+		name               string
+		excerptBytes       int
+		body               string
+		expectedErrMessage string
+	}{
+		{
+			name:               "excerpt disabled",
+			body:               malformed,
+			expectedErrMessage: "Status: 201; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
+		{
+			name:               "secrets redacted",
+			excerptBytes:       1024,
+			body:               malformed,
+			expectedErrMessage: `Status: 201; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input; body: "{\"credentials\":{\"password\":\"[REDACTED]\",\"api_key\":\"[REDACTED]\",\"uri\":\"postgres://[REDACTED]@db:5432/x\",\"port\":5432"`,
+		},
+		{
+			name:               "truncated",
+			excerptBytes:       10,
+			body:               `{"plain": tru`,
+			expectedErrMessage: `Status: 201; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input; body: "{\"plain\": ... (13 bytes total)"`,
+		},
+	}
+
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, Version2_13(), false, httpChecks{
+			body: defaultBindRequestBody,
+		}, httpReaction{
+			status: http.StatusCreated,
+			body:   tc.body,
+		})
+		klient.ErrorBodyExcerptBytes = tc.excerptBytes
+
+		_, err := klient.Bind(defaultBindRequest())
+		if err == nil || err.Error() != tc.expectedErrMessage {
+			t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+			continue
+		}
+
+		httpErr, _ := IsHTTPError(err)
+		var unmarshalErr ResponseUnmarshalError
+		if e, a := tc.excerptBytes > 0, errors.As(httpErr.ResponseError, &unmarshalErr); e != a {
+			t.Errorf("%v: expected a ResponseUnmarshalError %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+// blockingBody is a body whose reads block until unblock is closed, even
+// once the body is closed.
+type blockingBody struct {
+	unblock chan struct{}
+}
+
+func (b *blockingBody) Read(p []byte) (int, error) {
+	<-b.unblock
+	return 0, io.EOF
+}
+
+func (b *blockingBody) Close() error {
+	return nil
+}
+
+func TestBodyReadTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	cases := []struct {
+		name            string
+		timeout         time.Duration
+		body            io.ReadCloser
+		expectedTimeout bool
+	}{
+		{
+			name:            "body not read in time",
+			timeout:         20 * time.Millisecond,
+			body:            &blockingBody{unblock: unblock},
+			expectedTimeout: true,
+		},
+		{
+			name:    "body read in time",
+			timeout: time.Second,
+			body:    closer(okCatalogBytes),
+		},
+		{
+			name: "timeout disabled",
+			body: closer(okCatalogBytes),
+		},
+	}
+
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, LatestAPIVersion(), false, httpChecks{}, httpReaction{})
+		klient.BodyReadTimeout = tc.timeout
+		klient.newTimer = func(d time.Duration) *time.Timer {
+			if tc.expectedTimeout {
+				return time.NewTimer(0)
+			}
+			return time.NewTimer(time.Hour)
+		}
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       tc.body,
+			}, nil
+		}
+
+		_, err := klient.GetCatalog()
+		if tc.expectedTimeout {
+			if !IsBodyReadTimeoutError(err) {
+				t.Errorf("%v: expected a BodyReadTimeoutError, got %v", tc.name, err)
+			}
+			if e, a := "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: timed out reading the response body after 20ms", fmt.Sprint(err); e != a {
+				t.Errorf("%v: expected error %q, got %q", tc.name, e, a)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+	}
+}
+
+func TestResponseWarnings(t *testing.T) {
+	warnings := []string{`299 - "plan small is deprecated"`}
+
+	cases := []struct {
+		name string
+		call func(Client) ([]string, error)
+	}{
+		{
+			name: "GetCatalog",
+			call: func(c Client) ([]string, error) {
+				r, err := c.GetCatalog()
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "UpdateInstance",
+			call: func(c Client) ([]string, error) {
+				r, err := c.UpdateInstance(defaultUpdateInstanceRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "DeprovisionInstance",
+			call: func(c Client) ([]string, error) {
+				r, err := c.DeprovisionInstance(defaultDeprovisionRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "GetInstance",
+			call: func(c Client) ([]string, error) {
+				r, err := c.GetInstance(defaultGetInstanceRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "PollLastOperation",
+			call: func(c Client) ([]string, error) {
+				r, err := c.PollLastOperation(defaultLastOperationRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "Bind",
+			call: func(c Client) ([]string, error) {
+				r, err := c.Bind(defaultBindRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "Unbind",
+			call: func(c Client) ([]string, error) {
+				r, err := c.Unbind(defaultUnbindRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "GetBinding",
+			call: func(c Client) ([]string, error) {
+				r, err := c.GetBinding(defaultGetBindingRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+		{
+			name: "PollBindingLastOperation",
+			call: func(c Client) ([]string, error) {
+				r, err := c.PollBindingLastOperation(defaultBindingLastOperationRequest())
+				if err != nil {
+					return nil, err
+				}
+				return r.Warnings, nil
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		klient := newTestClient(t, tc.name, LatestAPIVersion(), true, httpChecks{}, httpReaction{})
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{WarningHeader: warnings},
+				Body:       closer(`{}`),
+			}, nil
+		}
+
+		actual, err := tc.call(klient)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := warnings, actual; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected warnings %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestResponseWarningsLoggedWhenVerbose(t *testing.T) {
+	var logs bytes.Buffer
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	if err := flags.Set("logtostderr", "false"); err != nil {
+		t.Fatal(err)
+	}
+	klog.SetOutput(&logs)
+	defer flags.Set("logtostderr", "true")
+
+	for _, verbose := range []bool{false, true} {
+		logs.Reset()
+
+		klient := newTestClient(t, "warnings logged", LatestAPIVersion(), false, httpChecks{}, httpReaction{
+			status: http.StatusOK,
+			body:   okCatalogBytes,
+			header: http.Header{WarningHeader: {`299 - "plan small is deprecated"`}},
+		})
+		klient.Verbose = verbose
+
+		if _, err := klient.GetCatalog(); err != nil {
+			t.Fatalf("verbose %v: unexpected error: %v", verbose, err)
+		}
+		klog.Flush()
+
+		if e, a := verbose, strings.Contains(logs.String(), `plan small is deprecated`); e != a {
+			t.Errorf("verbose %v: expected warning logged to be %v, logs: %q", verbose, e, logs.String())
+		}
+	}
+}
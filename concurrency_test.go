@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestConcurrentRequestsShareMaps hammers a single client with concurrent
+// requests built from one template, so that they share the template's
+// Parameters and Context maps. Run with -race to detect any write to them.
+func TestConcurrentRequestsShareMaps(t *testing.T) {
+	const workers = 50
+
+	parameters := map[string]interface{}{
+		"size":   "large",
+		"nested": map[string]interface{}{"replicas": float64(3)},
+	}
+	context := map[string]interface{}{
+		"platform": "cloudfoundry",
+	}
+	expectedParameters := map[string]interface{}{
+		"size":   "large",
+		"nested": map[string]interface{}{"replicas": float64(3)},
+	}
+	expectedContext := map[string]interface{}{
+		"platform": "cloudfoundry",
+	}
+
+	provisionTemplate := ProvisionRequest{
+		ServiceID:         testServiceID,
+		PlanID:            testPlanID,
+		OrganizationGUID:  "test-organization-guid",
+		SpaceGUID:         "test-space-guid",
+		AcceptsIncomplete: true,
+		Parameters:        parameters,
+		Context:           context,
+	}
+	bindTemplate := BindRequest{
+		ServiceID:  testServiceID,
+		PlanID:     testPlanID,
+		Parameters: parameters,
+		Context:    context,
+	}
+
+	klient := newTestClient(t, "concurrent requests", LatestAPIVersion(), true, httpChecks{}, httpReaction{})
+	klient.ValidateParametersAgainstSchemas = true
+	klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+		if request.Body != nil {
+			if _, err := io.ReadAll(request.Body); err != nil {
+				return nil, err
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       closer("{}"),
+		}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			r := provisionTemplate
+			r.InstanceID = fmt.Sprintf("instance-%d", i)
+			if _, err := klient.ProvisionInstance(&r); err != nil {
+				t.Errorf("unexpected provision error: %v", err)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			r := bindTemplate
+			r.InstanceID = fmt.Sprintf("instance-%d", i)
+			r.BindingID = fmt.Sprintf("binding-%d", i)
+			if _, err := klient.Bind(&r); err != nil {
+				t.Errorf("unexpected bind error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if !reflect.DeepEqual(expectedParameters, parameters) {
+		t.Errorf("parameters were modified; expected %v, got %v", expectedParameters, parameters)
+	}
+	if !reflect.DeepEqual(expectedContext, context) {
+		t.Errorf("context was modified; expected %v, got %v", expectedContext, context)
+	}
+}
+
+type countingBody struct {
+	io.ReadCloser
+	done func()
+}
+
+func (b *countingBody) Close() error {
+	b.done()
+	return b.ReadCloser.Close()
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	const (
+		workers = 20
+		limit   = 3
+	)
+
+	config := DefaultClientConfiguration()
+	config.URL = "https://example.com"
+	config.MaxConcurrentRequests = limit
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	klient := c.(*client)
+
+	var inFlight, maxInFlight int32
+	klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: &countingBody{
+				ReadCloser: closer(okCatalogBytes),
+				done:       func() { atomic.AddInt32(&inFlight, -1) },
+			},
+		}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := klient.GetCatalog(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&maxInFlight); max > limit {
+		t.Errorf("expected at most %d requests in flight, got %d", limit, max)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < limit; i++ {
+		if _, err := klient.acquireRequestSlot(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := klient.acquireRequestSlot(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled once all slots are taken, got %v", err)
+	}
+}
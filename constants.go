@@ -35,6 +35,10 @@ const (
 	// VarKeyPlanID is the name to use for a mux var representing a plan ID.
 	VarKeyPlanID = "plan_id"
 
+	// VarKeyParameters is the name to use for a query parameter carrying
+	// JSON-encoded parameters on requests that have no body.
+	VarKeyParameters = "parameters"
+
 	// VarKeyOperation is the name to use for a mux var representing an
 	// operation.
 	VarKeyOperation = "operation"
@@ -46,4 +50,16 @@ const (
 	// PlatformCloudFoundry is the name for Cloud Foundry in the Platform field
 	// of OriginatingIdentity.
 	PlatformCloudFoundry = "cloudfoundry"
+
+	// RequiresSyslogDrain is the Service.Requires permission for services
+	// that stream application logs to a syslog drain.
+	RequiresSyslogDrain = "syslog_drain"
+
+	// RequiresRouteForwarding is the Service.Requires permission for services
+	// that proxy requests to an application.
+	RequiresRouteForwarding = "route_forwarding"
+
+	// RequiresVolumeMount is the Service.Requires permission for services
+	// that mount volumes into application containers.
+	RequiresVolumeMount = "volume_mount"
 )
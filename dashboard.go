@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Dashboard describes a web-based management user interface for a service
+// instance.  Some brokers return a list of dashboards, or a dashboard
+// object, in place of the dashboard_url string defined by the spec.
+type Dashboard struct {
+	// URL is the URL of the dashboard.
+	URL string `json:"url"`
+	// Label is an optional human-readable label for the dashboard.
+	Label string `json:"label,omitempty"`
+}
+
+// dashboardField decodes the dashboard_url field of a response, which is
+// either a string, as defined by the spec, or the extended form used by some
+// brokers: a dashboard object, or a list of dashboard objects or strings.
+type dashboardField struct {
+	// URL is the URL given in the simple form, or the URL of the first
+	// dashboard given in the extended form.
+	URL *string
+	// Dashboards holds the dashboards given in the extended form.
+	Dashboards []Dashboard
+}
+
+func (f *dashboardField) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch data[0] {
+	case '"':
+		var url string
+		if err := json.Unmarshal(data, &url); err != nil {
+			return err
+		}
+		f.URL = &url
+		return nil
+	case '{':
+		var dashboard Dashboard
+		if err := json.Unmarshal(data, &dashboard); err != nil {
+			return err
+		}
+		f.Dashboards = []Dashboard{dashboard}
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return err
+		}
+		for _, element := range elements {
+			var dashboard Dashboard
+			if err := json.Unmarshal(element, &dashboard.URL); err != nil {
+				if err := json.Unmarshal(element, &dashboard); err != nil {
+					return err
+				}
+			}
+			f.Dashboards = append(f.Dashboards, dashboard)
+		}
+	default:
+		return fmt.Errorf("dashboard_url: unexpected value %s", data)
+	}
+
+	if len(f.Dashboards) > 0 {
+		url := f.Dashboards[0].URL
+		f.URL = &url
+	}
+	return nil
+}
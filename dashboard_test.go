@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDashboardFieldUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name               string
+		body               string
+		expectedURL        *string
+		expectedDashboards []Dashboard
+		expectedErr        bool
+	}{
+		{
+			name: "missing",
+			body: `{}`,
+		},
+		{
+			name: "null",
+			body: `{"dashboard_url": null}`,
+		},
+		{
+			name:        "string",
+			body:        `{"dashboard_url": "https://example.com/dashboard"}`,
+			expectedURL: strPtr("https://example.com/dashboard"),
+		},
+		{
+			name:        "object",
+			body:        `{"dashboard_url": {"url": "https://example.com/dashboard", "label": "main"}}`,
+			expectedURL: strPtr("https://example.com/dashboard"),
+			expectedDashboards: []Dashboard{
+				{URL: "https://example.com/dashboard", Label: "main"},
+			},
+		},
+		{
+			name:        "list of objects and strings",
+			body:        `{"dashboard_url": ["https://example.com/dashboard", {"url": "https://example.com/admin", "label": "admin"}]}`,
+			expectedURL: strPtr("https://example.com/dashboard"),
+			expectedDashboards: []Dashboard{
+				{URL: "https://example.com/dashboard"},
+				{URL: "https://example.com/admin", Label: "admin"},
+			},
+		},
+		{
+			name: "empty list",
+			body: `{"dashboard_url": []}`,
+		},
+		{
+			name:        "number",
+			body:        `{"dashboard_url": 42}`,
+			expectedErr: true,
+		},
+		{
+			name:        "list holding a number",
+			body:        `{"dashboard_url": [42]}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		var obj struct {
+			DashboardURL dashboardField `json:"dashboard_url"`
+		}
+		err := json.Unmarshal([]byte(tc.body), &obj)
+		if tc.expectedErr {
+			if err == nil {
+				t.Errorf("%v: expected error, got none", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		if e, a := tc.expectedURL, obj.DashboardURL.URL; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected URL; expected %v, got %v", tc.name, Deref(e, "<nil>"), Deref(a, "<nil>"))
+		}
+		if e, a := tc.expectedDashboards, obj.DashboardURL.Dashboards; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected dashboards; expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"k8s.io/klog/v2"
+)
+
+// bindRequestDeprecations returns a warning for each deprecated field set in
+// the given bind request.
+func bindRequestDeprecations(r *BindRequest) []string {
+	var warnings []string
+	if r.AppGUID != nil {
+		warnings = append(warnings, "BindRequest.AppGUID is deprecated and is not sent; use BindResource.AppGUID (bind_resource.app_guid) instead")
+	}
+	return warnings
+}
+
+// previousValuesDeprecations returns a warning for each deprecated field set
+// in the given previous values of an update request.
+func previousValuesDeprecations(pv *PreviousValues) []string {
+	if pv == nil {
+		return nil
+	}
+
+	var warnings []string
+	if pv.ServiceID != "" {
+		warnings = append(warnings, "PreviousValues.ServiceID is deprecated; the service of an instance cannot change")
+	}
+	if pv.OrgID != "" {
+		warnings = append(warnings, "PreviousValues.OrgID is deprecated; provide the organization in the Context of the request instead")
+	}
+	if pv.SpaceID != "" {
+		warnings = append(warnings, "PreviousValues.SpaceID is deprecated; provide the space in the Context of the request instead")
+	}
+	return warnings
+}
+
+// warnDeprecated logs the given warnings about deprecated fields if the
+// client is configured to.
+func (c *client) warnDeprecated(warnings []string) {
+	if !c.WarnOnDeprecatedFields {
+		return
+	}
+	for _, warning := range warnings {
+		klog.Warningf("broker %q: %s", c.Name, warning)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBindRequestDeprecations(t *testing.T) {
+	appGUID := "app-guid"
+
+	cases := []struct {
+		name     string
+		request  *BindRequest
+		expected []string
+	}{
+		{
+			name:    "no deprecated fields",
+			request: defaultBindRequest(),
+		},
+		{
+			name: "app guid in bind resource",
+			request: func() *BindRequest {
+				r := defaultBindRequest()
+				r.BindResource = &BindResource{AppGUID: &appGUID}
+				return r
+			}(),
+		},
+		{
+			name: "deprecated app guid",
+			request: func() *BindRequest {
+				r := defaultBindRequest()
+				r.AppGUID = &appGUID
+				return r
+			}(),
+			expected: []string{
+				"BindRequest.AppGUID is deprecated and is not sent; use BindResource.AppGUID (bind_resource.app_guid) instead",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, bindRequestDeprecations(tc.request); !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected warnings; expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestPreviousValuesDeprecations(t *testing.T) {
+	cases := []struct {
+		name           string
+		previousValues *PreviousValues
+		expected       []string
+	}{
+		{
+			name: "no previous values",
+		},
+		{
+			name:           "plan ID only",
+			previousValues: &PreviousValues{PlanID: testPlanID},
+		},
+		{
+			name: "deprecated fields",
+			previousValues: &PreviousValues{
+				PlanID:    testPlanID,
+				ServiceID: testServiceID,
+				OrgID:     "org-id",
+				SpaceID:   "space-id",
+			},
+			expected: []string{
+				"PreviousValues.ServiceID is deprecated; the service of an instance cannot change",
+				"PreviousValues.OrgID is deprecated; provide the organization in the Context of the request instead",
+				"PreviousValues.SpaceID is deprecated; provide the space in the Context of the request instead",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, previousValuesDeprecations(tc.previousValues); !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected warnings; expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestBindWarnsOnDeprecatedFields(t *testing.T) {
+	appGUID := "app-guid"
+	request := defaultBindRequest()
+	request.AppGUID = &appGUID
+
+	klient := newTestClient(t, "deprecated app guid", Version2_13(), false, httpChecks{
+		body: `{"service_id":"test-service-id","plan_id":"test-plan-id"}`,
+	}, httpReaction{
+		status: http.StatusCreated,
+		body:   successBindResponseBody,
+	})
+	klient.WarnOnDeprecatedFields = true
+
+	if _, err := klient.Bind(request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v2
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -32,11 +34,21 @@ func (c *client) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionRespons
 		VarKeyServiceID: r.ServiceID,
 		VarKeyPlanID:    r.PlanID,
 	}
-	if r.AcceptsIncomplete {
-		params[AcceptsIncomplete] = "true"
+	acceptsIncomplete := c.acceptsIncomplete(r.AcceptsIncomplete)
+	setAcceptsIncomplete(params, acceptsIncomplete, r.SendAcceptsIncompleteFalse)
+	if len(r.Parameters) > 0 {
+		// Parameters are refused rather than dropped by older clients.
+		if err := c.validateClientVersionIsAtLeast(Version2_17()); err != nil {
+			return nil, err
+		}
+		encodedParameters, err := json.Marshal(r.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		params[VarKeyParameters] = string(encodedParameters)
 	}
 
-	response, err := c.prepareAndDo(http.MethodDelete, fullURL, params, nil, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("DeprovisionInstance", http.MethodDelete, fullURL, params, deleteRequestBody(r.SendEmptyBody), r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -47,10 +59,10 @@ func (c *client) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionRespons
 	}()
 
 	switch response.StatusCode {
-	case http.StatusOK, http.StatusGone:
-		return &DeprovisionResponse{}, nil
+	case http.StatusOK, http.StatusGone, http.StatusNoContent:
+		return &DeprovisionResponse{Warnings: responseWarnings(response)}, nil
 	case http.StatusAccepted:
-		if !r.AcceptsIncomplete {
+		if !acceptsIncomplete {
 			// If the client did not signify that it could handle asynchronous
 			// operations, a '202 Accepted' response should be treated as an error.
 			return nil, c.handleFailureResponse(response)
@@ -61,16 +73,18 @@ func (c *client) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionRespons
 			return nil, err
 		}
 
-		var opPtr *OperationKey
-		if responseBodyObj.Operation != nil {
-			opStr := *responseBodyObj.Operation
-			op := OperationKey(opStr)
-			opPtr = &op
+		opPtr, err := c.operationKey(responseBodyObj.asyncOperationFields)
+		if err != nil {
+			return nil, err
 		}
 
 		userResponse := &DeprovisionResponse{
 			Async:        true,
 			OperationKey: opPtr,
+			Warnings:     responseWarnings(response),
+		}
+		if c.EnableAlphaFeatures {
+			userResponse.PollDelay = c.pollDelay(response)
 		}
 
 		return userResponse, nil
@@ -80,17 +94,19 @@ func (c *client) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionRespons
 }
 
 func validateDeprovisionRequest(request *DeprovisionRequest) error {
+	var errs []error
+
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.ServiceID == "" {
-		return required("serviceID")
+		errs = append(errs, required("serviceID"))
 	}
 
 	if request.PlanID == "" {
-		return required("planID")
+		errs = append(errs, required("planID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
@@ -36,6 +36,14 @@ func defaultAsyncDeprovisionRequest() *DeprovisionRequest {
 	return r
 }
 
+func defaultDeprovisionRequestWithParameters() *DeprovisionRequest {
+	r := defaultDeprovisionRequest()
+	r.Parameters = map[string]interface{}{
+		"force": true,
+	}
+	return r
+}
+
 const successDeprovisionResponseBody = `{}`
 
 func successDeprovisionResponse() *DeprovisionResponse {
@@ -97,6 +105,45 @@ func TestDeprovisionInstance(t *testing.T) {
 			},
 			expectedResponse: successDeprovisionResponse(),
 		},
+		{
+			name: "success - no content",
+			httpReaction: httpReaction{
+				status: http.StatusNoContent,
+			},
+			expectedResponse: successDeprovisionResponse(),
+		},
+		{
+			name:    "success - no content to asynchronous request",
+			request: defaultAsyncDeprovisionRequest(),
+			httpChecks: httpChecks{
+				params: map[string]string{
+					AcceptsIncomplete: "true",
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusNoContent,
+			},
+			expectedResponse: successDeprovisionResponse(),
+		},
+		{
+			name: "success - empty body",
+			request: func() *DeprovisionRequest {
+				r := defaultDeprovisionRequest()
+				r.SendEmptyBody = true
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: "{}",
+				headers: map[string]string{
+					contentType: jsonType,
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   successDeprovisionResponseBody,
+			},
+			expectedResponse: successDeprovisionResponse(),
+		},
 		{
 			name:    "success - async",
 			request: defaultAsyncDeprovisionRequest(),
@@ -215,6 +262,29 @@ func TestDeprovisionInstance(t *testing.T) {
 			},
 			expectedResponse: successDeprovisionResponse(),
 		},
+		{
+			name:    "parameters sent for API version >= 2.17",
+			version: Version2_17(),
+			request: defaultDeprovisionRequestWithParameters(),
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   successDeprovisionResponseBody,
+			},
+			httpChecks: httpChecks{
+				params: map[string]string{
+					VarKeyServiceID:  string(testServiceID),
+					VarKeyPlanID:     string(testPlanID),
+					VarKeyParameters: `{"force":true}`,
+				},
+			},
+			expectedResponse: successDeprovisionResponse(),
+		},
+		{
+			name:               "parameters refused unless API version >= 2.17",
+			version:            Version2_16(),
+			request:            defaultDeprovisionRequestWithParameters(),
+			expectedErrMessage: "operation not allowed: must have API version >= 2.17. Current: 2.16",
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"net/http"
+)
+
+// BrokerCapabilities is the discovery document that some brokers expose,
+// as an extension to the Open Service Broker API, to describe what they
+// support.  Not part of the Open Service Broker API specification.
+type BrokerCapabilities struct {
+	// APIVersions lists the versions of the API the broker supports, such
+	// as "2.14".
+	APIVersions []string `json:"api_versions"`
+	// Extensions lists the extensions to the API the broker supports.
+	// Optional.
+	Extensions []string `json:"extensions,omitempty"`
+	// AuthMethods lists the ways the broker accepts to authenticate
+	// platforms, such as "basic" or "bearer".  Optional.
+	AuthMethods []string `json:"auth_methods,omitempty"`
+}
+
+// LatestAPIVersion returns the latest API version supported by both the
+// broker and this library, and false if they support none in common.
+func (b *BrokerCapabilities) LatestAPIVersion() (APIVersion, bool) {
+	supported := APIVersions()
+
+	var latest APIVersion
+	found := false
+	for _, label := range b.APIVersions {
+		version, ok := supported[label]
+		if ok && (!found || version.AtLeast(latest)) {
+			latest, found = version, true
+		}
+	}
+	return latest, found
+}
+
+// SupportsExtension returns whether the broker lists the given extension.
+func (b *BrokerCapabilities) SupportsExtension(extension string) bool {
+	return containsString(b.Extensions, extension)
+}
+
+// SupportsAuthMethod returns whether the broker lists the given
+// authentication method.
+func (b *BrokerCapabilities) SupportsAuthMethod(method string) bool {
+	return containsString(b.AuthMethods, method)
+}
+
+func (c *client) Discover(ctx context.Context) (*BrokerCapabilities, error) {
+	if c.DiscoveryPath == "" {
+		return nil, DiscoveryNotSupportedError{}
+	}
+
+	fullURL := c.URL + c.DiscoveryPath
+
+	response, err := c.prepareAndDoWithContext(ctx, "Discover", http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = drainReader(response.Body)
+		response.Body.Close()
+	}()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		capabilities := &BrokerCapabilities{}
+		if err := c.unmarshalResponse(response, capabilities); err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		return capabilities, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const okDiscoveryBytes = `{
+  "api_versions": ["2.13", "2.14", "3.0"],
+  "extensions": ["bindings-list", "maintenance-info"],
+  "auth_methods": ["basic", "bearer"]
+}`
+
+func okBrokerCapabilities() *BrokerCapabilities {
+	return &BrokerCapabilities{
+		APIVersions: []string{"2.13", "2.14", "3.0"},
+		Extensions:  []string{"bindings-list", "maintenance-info"},
+		AuthMethods: []string{"basic", "bearer"},
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	cases := []struct {
+		name               string
+		discoveryPath      string
+		httpReaction       httpReaction
+		expectedResponse   *BrokerCapabilities
+		expectedErrMessage string
+		expectedErr        error
+	}{
+		{
+			name:          "not supported",
+			discoveryPath: "-",
+			expectedErr:   DiscoveryNotSupportedError{},
+		},
+		{
+			name: "success",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   okDiscoveryBytes,
+			},
+			expectedResponse: okBrokerCapabilities(),
+		},
+		{
+			name: "200 with malformed response",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   malformedResponse,
+			},
+			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
+		{
+			name: "500 with conventional response",
+			httpReaction: httpReaction{
+				status: http.StatusInternalServerError,
+				body:   conventionalFailureResponseBody,
+			},
+			expectedErr: testHTTPStatusCodeError(),
+		},
+	}
+
+	for _, tc := range cases {
+		httpChecks := httpChecks{
+			URL: "/v2/discovery",
+		}
+
+		klient := newTestClient(t, tc.name, LatestAPIVersion(), false, httpChecks, tc.httpReaction)
+		klient.DiscoveryPath = "/v2/discovery"
+		if tc.discoveryPath == "-" {
+			klient.DiscoveryPath = ""
+		}
+
+		response, err := klient.Discover(context.Background())
+
+		doResponseChecks(t, tc.name, response, err, tc.expectedResponse, tc.expectedErrMessage, tc.expectedErr)
+	}
+}
+
+func TestBrokerCapabilitiesLatestAPIVersion(t *testing.T) {
+	cases := []struct {
+		name          string
+		versions      []string
+		expected      APIVersion
+		expectedFound bool
+	}{
+		{
+			name:          "unordered versions",
+			versions:      []string{"2.14", "3.0", "2.12"},
+			expected:      Version2_14(),
+			expectedFound: true,
+		},
+		{
+			name:     "no version in common",
+			versions: []string{"1.0", "3.0"},
+		},
+		{
+			name: "no versions",
+		},
+	}
+	for _, tc := range cases {
+		capabilities := &BrokerCapabilities{APIVersions: tc.versions}
+		version, found := capabilities.LatestAPIVersion()
+		if e, a := tc.expectedFound, found; e != a {
+			t.Errorf("%v: expected found %v, got %v", tc.name, e, a)
+		}
+		if e, a := tc.expected, version; e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+
+	capabilities := okBrokerCapabilities()
+	if !capabilities.SupportsExtension("bindings-list") || capabilities.SupportsExtension("Bindings-List") {
+		t.Error("unexpected extension support")
+	}
+	if !capabilities.SupportsAuthMethod("bearer") || capabilities.SupportsAuthMethod("oauth2") {
+		t.Error("unexpected auth method support")
+	}
+}
@@ -16,4 +16,17 @@ limitations under the License.
 
 // Package v2 contains a client for working with service brokers implementing
 // v2 of the Open Service Broker API.
+//
+// The types of this package encode slices in JSON following a single
+// convention, so that brokers built on them send what the spec expects:
+//
+//   - Arrays the spec requires, such as the services of a catalog, the plans
+//     of a service and the ports of an endpoint, are always sent, as [] when
+//     empty or nil, never as null.
+//   - Optional arrays held in a plain slice, such as the tags of a service,
+//     are omitted when empty or nil.
+//   - Optional arrays whose presence is meaningful, such as the volume mounts
+//     and endpoints of a binding, are held in a pointer to a slice: a nil
+//     pointer omits the field, and a pointer to an empty slice, such as
+//     Ptr([]Endpoint{}), sends [].
 package v2
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// dryRunReaction is the canned response of an operation in dry-run mode.
+type dryRunReaction struct {
+	status int
+	body   string
+}
+
+// dryRunReactions holds the canned success response of each operation in
+// dry-run mode, keyed by operation.  Operations not listed get a 200
+// response with an empty object.
+var dryRunReactions = map[string]dryRunReaction{
+	"GetCatalog":               {http.StatusOK, `{"services":[]}`},
+	"ProvisionInstance":        {http.StatusCreated, `{}`},
+	"Bind":                     {http.StatusCreated, `{}`},
+	"RotateBinding":            {http.StatusCreated, `{}`},
+	"GetBindings":              {http.StatusOK, `[]`},
+	"PollLastOperation":        {http.StatusOK, `{"state":"succeeded"}`},
+	"PollBindingLastOperation": {http.StatusOK, `{"state":"succeeded"}`},
+}
+
+// dryRunResponse returns the canned success response of the given operation
+// to the given request, in place of sending it to the broker.  The request
+// body is read in full, so that errors producing it still surface, and the
+// request identity is echoed as a broker would.
+func dryRunResponse(operation string, request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		if _, err := io.Copy(io.Discard, request.Body); err != nil {
+			return nil, err
+		}
+		_ = request.Body.Close()
+	}
+
+	reaction, ok := dryRunReactions[operation]
+	if !ok {
+		reaction = dryRunReaction{http.StatusOK, `{}`}
+	}
+
+	header := http.Header{}
+	header.Set(RequestIdentityheader, request.Header.Get(RequestIdentityheader))
+	return &http.Response{
+		Status:     http.StatusText(reaction.status),
+		StatusCode: reaction.status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(reaction.body)),
+		Request:    request,
+	}, nil
+}
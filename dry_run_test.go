@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var signed int32
+	config := DefaultClientConfiguration()
+	config.URL = server.URL
+	config.DryRun = true
+	config.RequestIdentityVerification = RequestIdentityVerificationStrict
+	config.SignRequest = func(*http.Request) error {
+		atomic.AddInt32(&signed, 1)
+		return nil
+	}
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operations := map[string]func() error{
+		"GetCatalog": func() error {
+			_, err := c.GetCatalog()
+			return err
+		},
+		"ProvisionInstance": func() error {
+			_, err := c.ProvisionInstance(defaultProvisionRequest())
+			return err
+		},
+		"UpdateInstance": func() error {
+			_, err := c.UpdateInstance(defaultUpdateInstanceRequest())
+			return err
+		},
+		"DeprovisionInstance": func() error {
+			_, err := c.DeprovisionInstance(defaultDeprovisionRequest())
+			return err
+		},
+		"PollLastOperation": func() error {
+			response, err := c.PollLastOperation(defaultLastOperationRequest())
+			if err == nil && response.State != StateSucceeded {
+				t.Errorf("expected a succeeded operation, got %v", response.State)
+			}
+			return err
+		},
+		"GetInstance": func() error {
+			_, err := c.GetInstance(defaultGetInstanceRequest())
+			return err
+		},
+		"Bind": func() error {
+			_, err := c.Bind(defaultBindRequest())
+			return err
+		},
+		"GetBinding": func() error {
+			_, err := c.GetBinding(defaultGetBindingRequest())
+			return err
+		},
+		"PollBindingLastOperation": func() error {
+			_, err := c.PollBindingLastOperation(defaultBindingLastOperationRequest())
+			return err
+		},
+		"RotateBinding": func() error {
+			_, err := c.RotateBinding(defaultRotateBindingRequest())
+			return err
+		},
+		"Unbind": func() error {
+			_, err := c.Unbind(defaultUnbindRequest())
+			return err
+		},
+	}
+	for name, operation := range operations {
+		if err := operation(); err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+	}
+
+	if _, err := c.ProvisionInstance(&ProvisionRequest{}); err == nil {
+		t.Error("expected invalid requests to be rejected in dry-run mode")
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("expected no request to reach the broker, got %d", n)
+	}
+	if e, a := int32(len(operations)), atomic.LoadInt32(&signed); e != a {
+		t.Errorf("expected %d signed requests, got %d", e, a)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"math"
+	"time"
+)
+
+// Seconds is a duration serialized in JSON as an integer number of seconds,
+// the convention of the OSB API for durations such as the
+// maximum_polling_duration of plans.  Durations of the client that are not
+// serialized, such as PollDelay, remain time.Duration.
+type Seconds int64
+
+// SecondsOf returns d as Seconds, truncated toward zero.
+func SecondsOf(d time.Duration) Seconds {
+	return Seconds(d / time.Second)
+}
+
+// Duration returns s as a time.Duration, clamped to the range of
+// time.Duration.
+func (s Seconds) Duration() time.Duration {
+	const maxSeconds = Seconds(math.MaxInt64 / int64(time.Second))
+	switch {
+	case s > maxSeconds:
+		return time.Duration(math.MaxInt64)
+	case s < -maxSeconds:
+		return time.Duration(math.MinInt64)
+	}
+	return time.Duration(s) * time.Second
+}
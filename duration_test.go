@@ -0,0 +1,96 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSecondsJSONRoundTrip(t *testing.T) {
+	type window struct {
+		Start    Seconds  `json:"start"`
+		Duration *Seconds `json:"duration,omitempty"`
+	}
+
+	cases := []struct {
+		name     string
+		value    window
+		expected string
+	}{
+		{
+			name:     "zero",
+			expected: `{"start":0}`,
+		},
+		{
+			name:     "set",
+			value:    window{Start: SecondsOf(90 * time.Second), Duration: Ptr(SecondsOf(time.Hour))},
+			expected: `{"start":90,"duration":3600}`,
+		},
+	}
+	for _, tc := range cases {
+		data, err := json.Marshal(tc.value)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := tc.expected, string(data); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+
+		var decoded window
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := tc.value.Start, decoded.Start; e != a {
+			t.Errorf("%v: expected start %v, got %v", tc.name, e, a)
+		}
+		if e, a := tc.value.Duration, decoded.Duration; (e == nil) != (a == nil) || (e != nil && *e != *a) {
+			t.Errorf("%v: expected duration %v, got %v", tc.name, e, a)
+		}
+	}
+
+	var s Seconds
+	if err := json.Unmarshal([]byte(`1.5`), &s); err == nil {
+		t.Errorf("expected an error for fractional seconds, got %v", s)
+	}
+}
+
+func TestSecondsDuration(t *testing.T) {
+	cases := []struct {
+		seconds  Seconds
+		expected time.Duration
+	}{
+		{seconds: 0, expected: 0},
+		{seconds: 60, expected: time.Minute},
+		{seconds: -1, expected: -time.Second},
+		{seconds: math.MaxInt64, expected: time.Duration(math.MaxInt64)},
+		{seconds: math.MinInt64, expected: time.Duration(math.MinInt64)},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, tc.seconds.Duration(); e != a {
+			t.Errorf("%d: expected %v, got %v", tc.seconds, e, a)
+		}
+	}
+
+	if e, a := Seconds(1), SecondsOf(1999*time.Millisecond); e != a {
+		t.Errorf("expected SecondsOf to truncate to %v, got %v", e, a)
+	}
+}
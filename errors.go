@@ -19,6 +19,8 @@ package v2
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // HTTPStatusCodeError is an error type that provides additional information
@@ -37,6 +39,7 @@ import (
 //
 // - IsGoneError
 // - IsConflictError
+// - IsRateLimitedError
 // - IsAsyncRequiredError
 // - IsAppGUIDRequiredError
 type HTTPStatusCodeError struct {
@@ -51,6 +54,11 @@ type HTTPStatusCodeError struct {
 	// ResponseError is set to the error that occurred when unmarshalling a
 	// response body from the broker.
 	ResponseError error
+	// RetryAfter is how long the broker asked the client to wait before
+	// retrying, through the Retry-After header of the response, typically
+	// with a 429 Too Many Requests or 503 Service Unavailable status.  It is
+	// nil if the header is absent or invalid.
+	RetryAfter *time.Duration
 }
 
 func (e HTTPStatusCodeError) Error() string {
@@ -66,6 +74,66 @@ func (e HTTPStatusCodeError) Error() string {
 	return fmt.Sprintf("Status: %v; ErrorMessage: %v; Description: %v; ResponseError: %v", e.StatusCode, errorMessage, description, e.ResponseError)
 }
 
+// Code returns the HTTP status code returned by the broker.
+func (e HTTPStatusCodeError) Code() int {
+	return e.StatusCode
+}
+
+// Message returns the machine-readable error string returned by the broker,
+// or an empty string if there is none.
+func (e HTTPStatusCodeError) Message() string {
+	if e.ErrorMessage == nil {
+		return ""
+	}
+	return *e.ErrorMessage
+}
+
+// Desc returns the human-readable description of the error returned by the
+// broker, or an empty string if there is none.
+func (e HTTPStatusCodeError) Desc() string {
+	if e.Description == nil {
+		return ""
+	}
+	return *e.Description
+}
+
+// IsRetryable returns whether the error represents a condition on the broker
+// side that may resolve itself, that is one of DefaultRetryableStatusCodes.
+// It is equivalent to IsRetryableFor(nil): other 5xx codes, such as 501 Not
+// Implemented, are not retryable.
+func (e HTTPStatusCodeError) IsRetryable() bool {
+	return e.IsRetryableFor(nil)
+}
+
+// DefaultRetryableStatusCodes are the status codes IsRetryableFor treats as
+// retryable when given no override: the 5xx codes of transient server or
+// gateway failures.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// IsRetryableFor returns whether the status code of the error is one of the
+// given retryable status codes, or of DefaultRetryableStatusCodes if none
+// are given, in which case it is equivalent to IsRetryable.  It lets callers retrying requests opt in to retrying codes
+// such as 408 Request Timeout or 429 Too Many Requests, which some brokers
+// return when overloaded while others use 408 for a permanent problem with
+// the request.
+func (e HTTPStatusCodeError) IsRetryableFor(retryableStatusCodes []int) bool {
+	if len(retryableStatusCodes) == 0 {
+		retryableStatusCodes = DefaultRetryableStatusCodes
+	}
+
+	for _, code := range retryableStatusCodes {
+		if e.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
 // IsHTTPError returns whether the error represents an HTTPStatusCodeError.  A
 // client method returning an HTTP error indicates that the broker returned an
 // error code and a correctly formed response body.
@@ -103,6 +171,18 @@ func IsConflictError(err error) bool {
 	return statusCodeError.StatusCode == http.StatusConflict
 }
 
+// IsRateLimitedError returns whether the error represents an HTTP TOO MANY
+// REQUESTS status, with which a broker rate-limits the client.  The RetryAfter
+// field of the error holds the wait the broker suggested, if any.
+func IsRateLimitedError(err error) bool {
+	statusCodeError, ok := err.(HTTPStatusCodeError)
+	if !ok {
+		return false
+	}
+
+	return statusCodeError.StatusCode == http.StatusTooManyRequests
+}
+
 // Constants are used to check for spec-mandated errors and their messages
 const (
 	AsyncErrorMessage               = "AsyncRequired"
@@ -113,6 +193,18 @@ const (
 	ConcurrencyErrorDescription     = "The Service Broker does not support concurrent requests that mutate the same resource."
 )
 
+// errorCodeEquals returns whether the error code returned by a broker
+// matches the given spec-mandated error code.  Brokers vary in the casing of
+// error codes, so codes are compared case-insensitively and snake_case
+// variants such as "concurrency_error" are accepted.
+func errorCodeEquals(code, expected string) bool {
+	return normalizeErrorCode(code) == normalizeErrorCode(expected)
+}
+
+func normalizeErrorCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "_", ""))
+}
+
 // IsAsyncRequiredError returns whether the error corresponds to the
 // conventional way of indicating that a service requires asynchronous
 // operations to perform an action.
@@ -130,7 +222,7 @@ func IsAsyncRequiredError(err error) bool {
 		return false
 	}
 
-	if *statusCodeError.ErrorMessage != AsyncErrorMessage {
+	if !errorCodeEquals(*statusCodeError.ErrorMessage, AsyncErrorMessage) {
 		return false
 	}
 
@@ -154,7 +246,7 @@ func IsAppGUIDRequiredError(err error) bool {
 		return false
 	}
 
-	if *statusCodeError.ErrorMessage != AppGUIDRequiredErrorMessage {
+	if !errorCodeEquals(*statusCodeError.ErrorMessage, AppGUIDRequiredErrorMessage) {
 		return false
 	}
 
@@ -178,7 +270,7 @@ func IsConcurrencyError(err error) bool {
 		return false
 	}
 
-	if *statusCodeError.ErrorMessage != ConcurrencyErrorMessage {
+	if !errorCodeEquals(*statusCodeError.ErrorMessage, ConcurrencyErrorMessage) {
 		return false
 	}
 
@@ -270,3 +362,240 @@ func IsRotateBindingNotAllowedError(err error) bool {
 	_, ok := err.(RotateBindingNotAllowedError)
 	return ok
 }
+
+// GetBindingsNotSupportedError is an error type signifying that listing the
+// bindings of an instance is not supported because the client has no
+// BindingsListPath configured.
+type GetBindingsNotSupportedError struct{}
+
+func (e GetBindingsNotSupportedError) Error() string {
+	return "GetBindings not supported: no bindings list path configured"
+}
+
+// IsGetBindingsNotSupportedError returns whether the error represents
+// GetBindings not being supported by the client.
+func IsGetBindingsNotSupportedError(err error) bool {
+	_, ok := err.(GetBindingsNotSupportedError)
+	return ok
+}
+
+// RequestIdentityMismatchError is an error type signifying that a broker
+// echoed a request identity different from the one sent by the client, which
+// usually points at a misbehaving proxy or broker.
+type RequestIdentityMismatchError struct {
+	// Meta holds the sent and received request identities.
+	Meta ResponseMeta
+}
+
+func (e RequestIdentityMismatchError) Error() string {
+	return fmt.Sprintf(
+		"request identity mismatch: sent %q, received %q",
+		e.Meta.SentRequestIdentity,
+		e.Meta.ReceivedRequestIdentity,
+	)
+}
+
+// IsRequestIdentityMismatchError returns whether the error represents a
+// mismatch between the sent and echoed request identities.
+func IsRequestIdentityMismatchError(err error) bool {
+	_, ok := err.(RequestIdentityMismatchError)
+	return ok
+}
+
+// AsyncOperationFailedError is an error type signifying that the broker
+// reported an asynchronous operation as failed.
+type AsyncOperationFailedError struct {
+	// Description is the description of the failure provided by the broker,
+	// if any.
+	Description *string
+}
+
+func (e AsyncOperationFailedError) Error() string {
+	description := "<nil>"
+	if e.Description != nil {
+		description = *e.Description
+	}
+	return fmt.Sprintf("asynchronous operation failed: %s", description)
+}
+
+// IsAsyncOperationFailedError returns whether the error represents an
+// asynchronous operation reported as failed by the broker.
+func IsAsyncOperationFailedError(err error) bool {
+	_, ok := err.(AsyncOperationFailedError)
+	return ok
+}
+
+// PollingTimeoutError is an error type signifying that the deadline or the
+// maximum number of polls of a polling helper was reached before the
+// asynchronous operation completed.
+type PollingTimeoutError struct {
+	// LastResponse is the last response to a poll of the operation, or nil
+	// if the deadline passed before the first poll.
+	LastResponse *LastOperationResponse
+}
+
+func (e PollingTimeoutError) Error() string {
+	if e.LastResponse == nil {
+		return "timed out waiting for asynchronous operation"
+	}
+	description := "<nil>"
+	if e.LastResponse.Description != nil {
+		description = *e.LastResponse.Description
+	}
+	return fmt.Sprintf("timed out waiting for asynchronous operation: last state: %s; description: %s", e.LastResponse.State, description)
+}
+
+// IsPollingTimeoutError returns whether the error represents a polling
+// helper giving up on an asynchronous operation after its deadline or
+// maximum number of polls.
+func IsPollingTimeoutError(err error) bool {
+	_, ok := err.(PollingTimeoutError)
+	return ok
+}
+
+// SchemaValidationError is an error type signifying that a value does not
+// conform to a JSON schema.
+type SchemaValidationError struct {
+	// Path is the location of the offending value, such as
+	// "parameters.size".
+	Path string
+	// Reason describes how the value violates the schema.
+	Reason string
+}
+
+func (e SchemaValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Reason)
+}
+
+// IsSchemaValidationError returns whether the error represents a value that
+// does not conform to a JSON schema.
+func IsSchemaValidationError(err error) bool {
+	_, ok := err.(SchemaValidationError)
+	return ok
+}
+
+// PlanNotUpdatableError is an error type signifying that an update request
+// attempts to change the plan of an instance whose plan cannot be updated.
+type PlanNotUpdatableError struct {
+	// ServiceID is the ID of the service of the instance.
+	ServiceID string
+	// PlanID is the ID of the current plan of the instance, if known.
+	PlanID string
+}
+
+func (e PlanNotUpdatableError) Error() string {
+	if e.PlanID == "" {
+		return fmt.Sprintf("plan updates are not supported by service %q", e.ServiceID)
+	}
+	return fmt.Sprintf("plan updates are not supported by plan %q of service %q", e.PlanID, e.ServiceID)
+}
+
+// IsPlanNotUpdatableError returns whether the error represents an attempt to
+// change the plan of an instance whose plan cannot be updated.
+func IsPlanNotUpdatableError(err error) bool {
+	_, ok := err.(PlanNotUpdatableError)
+	return ok
+}
+
+// BindingNotRotatableError is an error type signifying that a rotation was
+// requested for a binding whose plan does not support binding rotation.
+type BindingNotRotatableError struct {
+	// PlanID is the ID of the plan of the binding's instance.
+	PlanID string
+}
+
+func (e BindingNotRotatableError) Error() string {
+	return fmt.Sprintf("binding rotation is not supported by plan %q", e.PlanID)
+}
+
+// IsBindingNotRotatableError returns whether the error represents an attempt
+// to rotate a binding whose plan does not support binding rotation.
+func IsBindingNotRotatableError(err error) bool {
+	_, ok := err.(BindingNotRotatableError)
+	return ok
+}
+
+// ResponseUnmarshalError is an error type signifying that a response body
+// from the broker could not be unmarshalled.  It is set as the ResponseError
+// of the resulting HTTPStatusCodeError if the client is configured with
+// ErrorBodyExcerptBytes, to show the malformed payload without enabling
+// verbose logging.
+type ResponseUnmarshalError struct {
+	// Err is the unmarshalling error.
+	Err error
+	// BodyExcerpt is the start of the response body, with obvious secrets
+	// redacted.
+	BodyExcerpt string
+}
+
+func (e ResponseUnmarshalError) Error() string {
+	return fmt.Sprintf("%v; body: %q", e.Err, e.BodyExcerpt)
+}
+
+// Unwrap returns the unmarshalling error.
+func (e ResponseUnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// IDMismatchError is an error type signifying that the broker echoed, in
+// the body of its response, an instance or binding ID other than the
+// requested one, such as a buggy or malicious broker answering about another
+// tenant's instance.
+type IDMismatchError struct {
+	// Field is the name of the echoed field, instance_id or binding_id.
+	Field string
+	// Requested is the ID of the request.
+	Requested string
+	// Echoed is the ID of the response.
+	Echoed string
+}
+
+func (e IDMismatchError) Error() string {
+	return fmt.Sprintf("%s mismatch: requested %q, response holds %q", e.Field, e.Requested, e.Echoed)
+}
+
+// IsIDMismatchError returns whether the error represents a response holding
+// an instance or binding ID other than the requested one.
+func IsIDMismatchError(err error) bool {
+	_, ok := err.(IDMismatchError)
+	return ok
+}
+
+// BodyReadTimeoutError is an error type signifying that the body of a
+// response was not read within the BodyReadTimeout of the client, although
+// the broker had sent the headers of the response.
+type BodyReadTimeoutError struct {
+	// Timeout is the BodyReadTimeout of the client.
+	Timeout time.Duration
+}
+
+func (e BodyReadTimeoutError) Error() string {
+	return fmt.Sprintf("timed out reading the response body after %v", e.Timeout)
+}
+
+// IsBodyReadTimeoutError returns whether the error, or the ResponseError of
+// an HTTPStatusCodeError, represents a response body that was not read in
+// time.
+func IsBodyReadTimeoutError(err error) bool {
+	if statusCodeError, ok := IsHTTPError(err); ok {
+		err = statusCodeError.ResponseError
+	}
+	_, ok := err.(BodyReadTimeoutError)
+	return ok
+}
+
+// DiscoveryNotSupportedError is an error type signifying that discovering
+// the capabilities of the broker is not supported because the client has no
+// DiscoveryPath configured.
+type DiscoveryNotSupportedError struct{}
+
+func (e DiscoveryNotSupportedError) Error() string {
+	return "Discover not supported: no discovery path configured"
+}
+
+// IsDiscoveryNotSupportedError returns whether the error represents Discover
+// not being supported by the client.
+func IsDiscoveryNotSupportedError(err error) bool {
+	_, ok := err.(DiscoveryNotSupportedError)
+	return ok
+}
@@ -185,6 +185,42 @@ func TestIsAsyncRequiredError(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "asyncrequired error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("asyncrequired"),
+				Description:  strPtr(AsyncErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "async_required error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("async_required"),
+				Description:  strPtr(AsyncErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "ASYNC_REQUIRED error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("ASYNC_REQUIRED"),
+				Description:  strPtr(AsyncErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "other error code with matching description",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("async_required_mismatch"),
+				Description:  strPtr(AsyncErrorDescription),
+			},
+			expected: false,
+		},
 		{
 			name: "no error message",
 			err: HTTPStatusCodeError{
@@ -255,6 +291,42 @@ func TestIsAppGUIDRequiredError(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "requiresapp error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("requiresapp"),
+				Description:  strPtr(AppGUIDRequiredErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "requires_app error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("requires_app"),
+				Description:  strPtr(AppGUIDRequiredErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "Requires_App error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("Requires_App"),
+				Description:  strPtr(AppGUIDRequiredErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "other error code with matching description",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("requires_app_mismatch"),
+				Description:  strPtr(AppGUIDRequiredErrorDescription),
+			},
+			expected: false,
+		},
 		{
 			name: "no error message",
 			err: HTTPStatusCodeError{
@@ -325,6 +397,42 @@ func TestConcurrencyError(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "concurrencyerror error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("concurrencyerror"),
+				Description:  strPtr(ConcurrencyErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "concurrency_error error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("concurrency_error"),
+				Description:  strPtr(ConcurrencyErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "CONCURRENCY_ERROR error code",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("CONCURRENCY_ERROR"),
+				Description:  strPtr(ConcurrencyErrorDescription),
+			},
+			expected: true,
+		},
+		{
+			name: "other error code with matching description",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr("concurrency_error_mismatch"),
+				Description:  strPtr(ConcurrencyErrorDescription),
+			},
+			expected: false,
+		},
 		{
 			name: "no error message",
 			err: HTTPStatusCodeError{
@@ -388,6 +496,150 @@ func TestHttpStatusCodeError(t *testing.T) {
 	}
 }
 
+func TestHTTPStatusCodeErrorAccessors(t *testing.T) {
+	cases := []struct {
+		name              string
+		err               HTTPStatusCodeError
+		expectedCode      int
+		expectedMessage   string
+		expectedDesc      string
+		expectedRetryable bool
+	}{
+		{
+			name: "fully populated",
+			err: HTTPStatusCodeError{
+				StatusCode:    http.StatusServiceUnavailable,
+				ErrorMessage:  strPtr("TestError"),
+				Description:   strPtr("test description"),
+				ResponseError: errors.New("test response error"),
+			},
+			expectedCode:      http.StatusServiceUnavailable,
+			expectedMessage:   "TestError",
+			expectedDesc:      "test description",
+			expectedRetryable: true,
+		},
+		{
+			name: "client error",
+			err: HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr(AsyncErrorMessage),
+			},
+			expectedCode:    http.StatusUnprocessableEntity,
+			expectedMessage: AsyncErrorMessage,
+		},
+		{
+			name: "not implemented",
+			err: HTTPStatusCodeError{
+				StatusCode: http.StatusNotImplemented,
+			},
+			expectedCode: http.StatusNotImplemented,
+		},
+		{
+			name: "blank error",
+			err:  HTTPStatusCodeError{},
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expectedCode, tc.err.Code(); e != a {
+			t.Errorf("%v: unexpected code; expected %v, got %v", tc.name, e, a)
+		}
+		if e, a := tc.expectedMessage, tc.err.Message(); e != a {
+			t.Errorf("%v: unexpected message; expected %q, got %q", tc.name, e, a)
+		}
+		if e, a := tc.expectedDesc, tc.err.Desc(); e != a {
+			t.Errorf("%v: unexpected description; expected %q, got %q", tc.name, e, a)
+		}
+		if e, a := tc.expectedRetryable, tc.err.IsRetryable(); e != a {
+			t.Errorf("%v: unexpected retryable; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestIsRateLimitedError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "non-http error",
+			err:      errors.New("some error"),
+			expected: false,
+		},
+		{
+			name: "http non-rate-limited error",
+			err: HTTPStatusCodeError{
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			expected: false,
+		},
+		{
+			name: "http rate-limited error",
+			err: HTTPStatusCodeError{
+				StatusCode: http.StatusTooManyRequests,
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, IsRateLimitedError(tc.err); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestHTTPStatusCodeErrorIsRetryableFor(t *testing.T) {
+	cases := []struct {
+		name                 string
+		statusCode           int
+		retryableStatusCodes []int
+		expected             bool
+	}{
+		{
+			name:       "default 503",
+			statusCode: http.StatusServiceUnavailable,
+			expected:   true,
+		},
+		{
+			name:       "default 501",
+			statusCode: http.StatusNotImplemented,
+			expected:   false,
+		},
+		{
+			name:       "default 408",
+			statusCode: http.StatusRequestTimeout,
+			expected:   false,
+		},
+		{
+			name:                 "configured 408",
+			statusCode:           http.StatusRequestTimeout,
+			retryableStatusCodes: append([]int{http.StatusRequestTimeout, http.StatusTooManyRequests}, DefaultRetryableStatusCodes...),
+			expected:             true,
+		},
+		{
+			name:                 "configured 429",
+			statusCode:           http.StatusTooManyRequests,
+			retryableStatusCodes: append([]int{http.StatusRequestTimeout, http.StatusTooManyRequests}, DefaultRetryableStatusCodes...),
+			expected:             true,
+		},
+		{
+			name:                 "500 not configured",
+			statusCode:           http.StatusInternalServerError,
+			retryableStatusCodes: []int{http.StatusRequestTimeout},
+			expected:             false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := HTTPStatusCodeError{StatusCode: tc.statusCode}
+		if e, a := tc.expected, err.IsRetryableFor(tc.retryableStatusCodes); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
 func TestAsyncBindingOperationsNotAllowedError(t *testing.T) {
 	err := AsyncBindingOperationsNotAllowedError{
 		reason: "test reason",
@@ -17,6 +17,7 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"sync"
@@ -50,14 +51,17 @@ func NewFakeClient(config FakeClientConfiguration) *FakeClient {
 		UpdateInstanceReaction:           config.UpdateInstanceReaction,
 		DeprovisionReaction:              config.DeprovisionReaction,
 		GetInstanceReaction:              config.GetInstanceReaction,
+		InstanceExistsReaction:           config.InstanceExistsReaction,
 		PollLastOperationReaction:        config.PollLastOperationReaction,
 		PollLastOperationReactions:       config.PollLastOperationReactions,
 		PollBindingLastOperationReaction: config.PollBindingLastOperationReaction,
 		BindReaction:                     config.BindReaction,
 		UnbindReaction:                   config.UnbindReaction,
 		GetBindingReaction:               config.GetBindingReaction,
+		GetBindingsReaction:              config.GetBindingsReaction,
 		RotateBindingReaction:            config.RotateBindingReaction,
 		StatusReaction:                   config.StatusReaction,
+		DiscoverReaction:                 config.DiscoverReaction,
 	}
 }
 
@@ -68,14 +72,17 @@ type FakeClientConfiguration struct {
 	UpdateInstanceReaction           UpdateInstanceReactionInterface
 	DeprovisionReaction              DeprovisionReactionInterface
 	GetInstanceReaction              GetInstanceReactionInterface
+	InstanceExistsReaction           InstanceExistsReactionInterface
 	PollLastOperationReaction        PollLastOperationReactionInterface
 	PollLastOperationReactions       map[v2.OperationKey]*PollLastOperationReaction
 	PollBindingLastOperationReaction PollBindingLastOperationReactionInterface
 	BindReaction                     BindReactionInterface
 	UnbindReaction                   UnbindReactionInterface
 	GetBindingReaction               GetBindingReactionInterface
+	GetBindingsReaction              GetBindingsReactionInterface
 	RotateBindingReaction            RotateBindingReactionInterface
 	StatusReaction                   StatusReaction
+	DiscoverReaction                 DiscoverReactionInterface
 }
 
 // Action is a record of a method call on the FakeClient.
@@ -95,13 +102,17 @@ const (
 	UpdateInstance           ActionType = "UpdateInstance"
 	DeprovisionInstance      ActionType = "DeprovisionInstance"
 	GetInstance              ActionType = "GetInstance"
+	InstanceExists           ActionType = "InstanceExists"
 	PollLastOperation        ActionType = "PollLastOperation"
 	PollBindingLastOperation ActionType = "PollBindingLastOperation"
 	Bind                     ActionType = "Bind"
 	Unbind                   ActionType = "Unbind"
 	GetBinding               ActionType = "GetBinding"
+	GetBindings              ActionType = "GetBindings"
 	RotateBinding            ActionType = "RotateBinding"
 	Status                   ActionType = "Status"
+	Refresh                  ActionType = "Refresh"
+	Discover                 ActionType = "Discover"
 )
 
 // FakeClient is a fake implementation of the v2.Client interface. It records
@@ -114,20 +125,30 @@ type FakeClient struct {
 	UpdateInstanceReaction           UpdateInstanceReactionInterface
 	DeprovisionReaction              DeprovisionReactionInterface
 	GetInstanceReaction              GetInstanceReactionInterface
+	InstanceExistsReaction           InstanceExistsReactionInterface
 	PollLastOperationReaction        PollLastOperationReactionInterface
 	PollLastOperationReactions       map[v2.OperationKey]*PollLastOperationReaction
 	PollBindingLastOperationReaction PollBindingLastOperationReactionInterface
 	BindReaction                     BindReactionInterface
 	UnbindReaction                   UnbindReactionInterface
 	GetBindingReaction               GetBindingReactionInterface
+	GetBindingsReaction              GetBindingsReactionInterface
 	RotateBindingReaction            RotateBindingReactionInterface
 	StatusReaction                   StatusReactionInterface
+	DiscoverReaction                 DiscoverReactionInterface
+
+	// Configuration is returned by the ConfigurationReader methods.
+	Configuration v2.ClientConfiguration
 
 	sync.Mutex
 	actions []Action
 }
 
 var _ v2.Client = &FakeClient{}
+var _ v2.Refresher = &FakeClient{}
+var _ v2.BindingLister = &FakeClient{}
+var _ v2.InstanceChecker = &FakeClient{}
+var _ v2.Discoverer = &FakeClient{}
 
 // Actions is a method defined on FakeClient that returns the actions taken on
 // it.
@@ -211,6 +232,21 @@ func (c *FakeClient) GetInstance(*v2.GetInstanceRequest) (*v2.GetInstanceRespons
 	return nil, UnexpectedActionError()
 }
 
+// InstanceExists implements the v2.InstanceChecker interface for the
+// FakeClient.
+func (c *FakeClient) InstanceExists(_ context.Context, instanceID string) (bool, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.actions = append(c.actions, Action{Type: InstanceExists, Request: instanceID})
+
+	if c.InstanceExistsReaction != nil {
+		return c.InstanceExistsReaction.react(instanceID)
+	}
+
+	return false, UnexpectedActionError()
+}
+
 // PollLastOperation implements the Client.PollLastOperation method on the
 // FakeClient.
 func (c *FakeClient) PollLastOperation(r *v2.LastOperationRequest) (*v2.LastOperationResponse, error) {
@@ -285,6 +321,20 @@ func (c *FakeClient) GetBinding(*v2.GetBindingRequest) (*v2.GetBindingResponse,
 	return nil, UnexpectedActionError()
 }
 
+// GetBindings implements the v2.BindingLister interface for the FakeClient.
+func (c *FakeClient) GetBindings(instanceID string) ([]v2.GetBindingResponse, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.actions = append(c.actions, Action{Type: GetBindings, Request: instanceID})
+
+	if c.GetBindingsReaction != nil {
+		return c.GetBindingsReaction.react(instanceID)
+	}
+
+	return nil, UnexpectedActionError()
+}
+
 // RotateBinding implements the Client.RotateBinding method for the FakeClient.
 func (c *FakeClient) RotateBinding(r *v2.RotateBindingRequest) (*v2.BindResponse, error) {
 	c.Mutex.Lock()
@@ -313,6 +363,57 @@ func (c *FakeClient) GetStatus() (*v2.GetStatusResponse, error) {
 	return nil, UnexpectedActionError()
 }
 
+// BrokerURL implements the Client.BrokerURL method for the FakeClient.
+func (c *FakeClient) BrokerURL() string {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	return c.Configuration.URL
+}
+
+// BrokerAPIVersion implements the Client.BrokerAPIVersion method for the
+// FakeClient.
+func (c *FakeClient) BrokerAPIVersion() v2.APIVersion {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	return c.Configuration.APIVersion
+}
+
+// AlphaEnabled implements the Client.AlphaEnabled method for the FakeClient.
+func (c *FakeClient) AlphaEnabled() bool {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	return c.Configuration.EnableAlphaFeatures
+}
+
+// Refresh implements the v2.Refresher interface for the FakeClient.  It
+// replaces the Configuration returned by the ConfigurationReader methods.
+func (c *FakeClient) Refresh(config *v2.ClientConfiguration) error {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.actions = append(c.actions, Action{Type: Refresh, Request: config})
+	c.Configuration = *config
+
+	return nil
+}
+
+// Discover implements the v2.Discoverer interface for the FakeClient.
+func (c *FakeClient) Discover(_ context.Context) (*v2.BrokerCapabilities, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.actions = append(c.actions, Action{Type: Discover})
+
+	if c.DiscoverReaction != nil {
+		return c.DiscoverReaction.react()
+	}
+
+	return nil, UnexpectedActionError()
+}
+
 // UnexpectedActionError returns an error message when an action is not found
 // in the FakeClient's action array.
 func UnexpectedActionError() error {
@@ -443,6 +544,30 @@ func (r DynamicGetInstanceReaction) react() (*v2.GetInstanceResponse, error) {
 	return r()
 }
 
+// InstanceExistsReactionInterface defines the reaction to InstanceExists
+// requests.
+type InstanceExistsReactionInterface interface {
+	react(instanceID string) (bool, error)
+}
+
+type InstanceExistsReaction struct {
+	Exists bool
+	Error  error
+}
+
+func (r *InstanceExistsReaction) react(_ string) (bool, error) {
+	if r == nil {
+		return false, UnexpectedActionError()
+	}
+	return r.Exists, r.Error
+}
+
+type DynamicInstanceExistsReaction func(instanceID string) (bool, error)
+
+func (r DynamicInstanceExistsReaction) react(instanceID string) (bool, error) {
+	return r(instanceID)
+}
+
 // PollLastOperationReactionInterface defines the reaction to PollLastOperation
 // requests.
 type PollLastOperationReactionInterface interface {
@@ -560,6 +685,29 @@ func (r DynamicGetBindingReaction) react() (*v2.GetBindingResponse, error) {
 	return r()
 }
 
+// GetBindingsReactionInterface defines the reaction to GetBindings requests.
+type GetBindingsReactionInterface interface {
+	react(instanceID string) ([]v2.GetBindingResponse, error)
+}
+
+type GetBindingsReaction struct {
+	Response []v2.GetBindingResponse
+	Error    error
+}
+
+func (r *GetBindingsReaction) react(_ string) ([]v2.GetBindingResponse, error) {
+	if r == nil {
+		return nil, UnexpectedActionError()
+	}
+	return r.Response, r.Error
+}
+
+type DynamicGetBindingsReaction func(instanceID string) ([]v2.GetBindingResponse, error)
+
+func (r DynamicGetBindingsReaction) react(instanceID string) ([]v2.GetBindingResponse, error) {
+	return r(instanceID)
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -606,6 +754,31 @@ func (r DynamicStatusReaction) react() (*v2.GetStatusResponse, error) {
 	return r()
 }
 
+// DiscoverReactionInterface defines the reaction to Discover requests.
+type DiscoverReactionInterface interface {
+	react() (*v2.BrokerCapabilities, error)
+}
+
+// DiscoverReaction sets a static reaction to Discover requests.
+type DiscoverReaction struct {
+	Response *v2.BrokerCapabilities
+	Error    error
+}
+
+func (r *DiscoverReaction) react() (*v2.BrokerCapabilities, error) {
+	if r == nil {
+		return nil, UnexpectedActionError()
+	}
+	return r.Response, r.Error
+}
+
+// DynamicDiscoverReaction sets a dynamic reaction to Discover requests.
+type DynamicDiscoverReaction func() (*v2.BrokerCapabilities, error)
+
+func (r DynamicDiscoverReaction) react() (*v2.BrokerCapabilities, error) {
+	return r()
+}
+
 // AsyncRequiredError returns error for required asynchronous operations.
 func AsyncRequiredError() error {
 	return v2.HTTPStatusCodeError{
@@ -17,8 +17,10 @@ limitations under the License.
 package fake_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
+	"sync"
 	"testing"
 
 	v2 "github.com/orange-cloudfoundry/go-open-service-broker-client/v2"
@@ -483,6 +485,145 @@ func TestGetInstance(t *testing.T) {
 	}
 }
 
+func TestInstanceExists(t *testing.T) {
+	cases := []struct {
+		name     string
+		reaction fake.InstanceExistsReactionInterface
+		exists   bool
+		err      error
+	}{
+		{
+			name: "unexpected action",
+			err:  fake.UnexpectedActionError(),
+		},
+		{
+			name: "exists",
+			reaction: &fake.InstanceExistsReaction{
+				Exists: true,
+			},
+			exists: true,
+		},
+		{
+			name: "error",
+			reaction: &fake.InstanceExistsReaction{
+				Error: errors.New("oops"),
+			},
+			err: errors.New("oops"),
+		},
+		{
+			name: "dynamic response",
+			reaction: fake.DynamicInstanceExistsReaction(func(instanceID string) (bool, error) {
+				return instanceID == "instance-id", nil
+			}),
+			exists: true,
+		},
+		{
+			name: "nil static reaction",
+			reaction: func() fake.InstanceExistsReactionInterface {
+				var nilStaticReaction *fake.InstanceExistsReaction
+				return nilStaticReaction
+			}(),
+			err: fake.UnexpectedActionError(),
+		},
+	}
+
+	for _, tc := range cases {
+		fakeClient := &fake.FakeClient{
+			InstanceExistsReaction: tc.reaction,
+		}
+
+		exists, err := fakeClient.InstanceExists(context.Background(), "instance-id")
+
+		if e, a := tc.exists, exists; e != a {
+			t.Errorf("%v: unexpected result; expected %v, got %v", tc.name, e, a)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("%v: unexpected error; expected %+v, got %+v", tc.name, tc.err, err)
+		}
+
+		actions := fakeClient.Actions()
+		if e, a := 1, len(actions); e != a {
+			t.Errorf("%v: unexpected actions; expected %v, got %v; actions = %+v", tc.name, e, a, actions)
+		}
+		if e, a := fake.InstanceExists, actions[0].Type; e != a {
+			t.Errorf("%v: unexpected action type; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestGetBindings(t *testing.T) {
+	bindings := []v2.GetBindingResponse{{Credentials: map[string]interface{}{"foo": "bar"}}}
+
+	cases := []struct {
+		name     string
+		reaction fake.GetBindingsReactionInterface
+		response []v2.GetBindingResponse
+		err      error
+	}{
+		{
+			name: "unexpected action",
+			err:  fake.UnexpectedActionError(),
+		},
+		{
+			name: "response",
+			reaction: &fake.GetBindingsReaction{
+				Response: bindings,
+			},
+			response: bindings,
+		},
+		{
+			name: "error",
+			reaction: &fake.GetBindingsReaction{
+				Error: v2.GetBindingsNotSupportedError{},
+			},
+			err: v2.GetBindingsNotSupportedError{},
+		},
+		{
+			name: "dynamic response",
+			reaction: fake.DynamicGetBindingsReaction(func(instanceID string) ([]v2.GetBindingResponse, error) {
+				if instanceID != "instance-id" {
+					return nil, errors.New("unexpected instance ID")
+				}
+				return bindings, nil
+			}),
+			response: bindings,
+		},
+		{
+			name: "nil static reaction",
+			reaction: func() fake.GetBindingsReactionInterface {
+				var nilStaticReaction *fake.GetBindingsReaction
+				return nilStaticReaction
+			}(),
+			err: fake.UnexpectedActionError(),
+		},
+	}
+
+	for _, tc := range cases {
+		fakeClient := &fake.FakeClient{
+			GetBindingsReaction: tc.reaction,
+		}
+
+		response, err := fakeClient.GetBindings("instance-id")
+
+		if !reflect.DeepEqual(tc.response, response) {
+			t.Errorf("%v: unexpected response; expected %+v, got %+v", tc.name, tc.response, response)
+		}
+
+		if !reflect.DeepEqual(tc.err, err) {
+			t.Errorf("%v: unexpected error; expected %+v, got %+v", tc.name, tc.err, err)
+		}
+
+		actions := fakeClient.Actions()
+		if e, a := 1, len(actions); e != a {
+			t.Errorf("%v: unexpected actions; expected %v, got %v; actions = %+v", tc.name, e, a, actions)
+		}
+		if e, a := fake.GetBindings, actions[0].Type; e != a {
+			t.Errorf("%v: unexpected action type; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
 func lastOperationResponse() *v2.LastOperationResponse {
 	return &v2.LastOperationResponse{
 		State: v2.StateSucceeded,
@@ -1093,3 +1234,104 @@ func TestNewFakeClient(t *testing.T) {
 	}
 
 }
+
+func TestRefresh(t *testing.T) {
+	fakeClient := &fake.FakeClient{}
+
+	config := v2.DefaultClientConfiguration()
+	config.URL = "https://broker.example.com"
+	config.APIVersion = v2.Version2_14()
+	if err := fakeClient.Refresh(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := config.URL, fakeClient.BrokerURL(); e != a {
+		t.Errorf("unexpected broker URL; expected %v, got %v", e, a)
+	}
+	if e, a := config.APIVersion, fakeClient.BrokerAPIVersion(); e != a {
+		t.Errorf("unexpected API version; expected %v, got %v", e, a)
+	}
+
+	actions := fakeClient.Actions()
+	if e, a := 1, len(actions); e != a {
+		t.Fatalf("unexpected actions; expected %v, got %v; actions = %+v", e, a, actions)
+	}
+	if e, a := fake.Refresh, actions[0].Type; e != a {
+		t.Errorf("unexpected action type; expected %v, got %v", e, a)
+	}
+}
+
+func TestRefreshConcurrentWithConfigurationReads(t *testing.T) {
+	fakeClient := &fake.FakeClient{}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = fakeClient.Refresh(v2.DefaultClientConfiguration())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = fakeClient.BrokerURL()
+			_ = fakeClient.BrokerAPIVersion()
+			_ = fakeClient.AlphaEnabled()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestDiscover(t *testing.T) {
+	capabilities := &v2.BrokerCapabilities{APIVersions: []string{"2.14"}}
+
+	cases := []struct {
+		name             string
+		client           *fake.FakeClient
+		expectedResponse *v2.BrokerCapabilities
+		expectedErr      error
+	}{
+		{
+			name: "response",
+			client: fake.NewFakeClient(fake.FakeClientConfiguration{
+				DiscoverReaction: &fake.DiscoverReaction{Response: capabilities},
+			}),
+			expectedResponse: capabilities,
+		},
+		{
+			name: "dynamic reaction",
+			client: fake.NewFakeClient(fake.FakeClientConfiguration{
+				DiscoverReaction: fake.DynamicDiscoverReaction(func() (*v2.BrokerCapabilities, error) {
+					return nil, v2.DiscoveryNotSupportedError{}
+				}),
+			}),
+			expectedErr: v2.DiscoveryNotSupportedError{},
+		},
+		{
+			name:        "unexpected action",
+			client:      &fake.FakeClient{},
+			expectedErr: fake.UnexpectedActionError(),
+		},
+	}
+
+	for _, tc := range cases {
+		response, err := tc.client.Discover(context.Background())
+
+		if !reflect.DeepEqual(tc.expectedErr, err) {
+			t.Errorf("%v: unexpected error; expected %v, got %v", tc.name, tc.expectedErr, err)
+		}
+		if e, a := tc.expectedResponse, response; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected response; expected %+v, got %+v", tc.name, e, a)
+		}
+
+		actions := tc.client.Actions()
+		if e, a := 1, len(actions); e != a {
+			t.Errorf("%v: unexpected actions; expected %v, got %v; actions = %+v", tc.name, e, a, actions)
+			continue
+		}
+		if e, a := fake.Discover, actions[0].Type; e != a {
+			t.Errorf("%v: unexpected action type; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
@@ -30,12 +30,15 @@ func (c *client) GetBinding(r *GetBindingRequest) (*GetBindingResponse, error) {
 
 	fullURL := fmt.Sprintf(bindingURLFmt, c.URL, r.InstanceID, r.BindingID)
 
-	params := map[string]string{
-		"service_id": r.ServiceID,
-		"plan_id":    r.PlanID,
+	params := map[string]string{}
+	if r.ServiceID != "" {
+		params["service_id"] = r.ServiceID
+	}
+	if r.PlanID != "" {
+		params["plan_id"] = r.PlanID
 	}
 
-	response, err := c.prepareAndDo(http.MethodGet, fullURL, params, nil /* request body */, nil /* originating identity */)
+	response, err := c.prepareAndDo("GetBinding", http.MethodGet, fullURL, params, nil /* request body */, nil /* originating identity */)
 	if err != nil {
 		return nil, err
 	}
@@ -48,13 +51,21 @@ func (c *client) GetBinding(r *GetBindingRequest) (*GetBindingResponse, error) {
 	switch response.StatusCode {
 	case http.StatusOK:
 		userResponse := &GetBindingResponse{}
-		if err := c.unmarshalResponse(response, userResponse); err != nil {
+		body := struct {
+			*GetBindingResponse
+			echoedIDs
+		}{GetBindingResponse: userResponse}
+		if err := c.unmarshalGetResponse(response, &body); err != nil {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
+		if err := body.verify(r.InstanceID, r.BindingID); err != nil {
+			return nil, err
+		}
 
 		if !c.EnableAlphaFeatures {
 			userResponse.Endpoints = nil
 		}
+		userResponse.Warnings = responseWarnings(response)
 
 		return userResponse, nil
 	default:
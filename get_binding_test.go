@@ -99,6 +99,7 @@ func TestGetBinding(t *testing.T) {
 		expectedResponse   *GetBindingResponse
 		expectedErrMessage string
 		expectedErr        error
+		acceptEmpty        bool
 	}{
 		{
 			name: "success",
@@ -131,6 +132,52 @@ func TestGetBinding(t *testing.T) {
 			},
 			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
 		},
+		{
+			name: "200 with matching echoed IDs",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"instance_id":"test-instance-id","binding_id":"test-binding-id","credentials":{"foo":"bar"}}`,
+			},
+			expectedResponse: &GetBindingResponse{Credentials: map[string]interface{}{"foo": "bar"}},
+		},
+		{
+			name: "200 with mismatched echoed binding ID",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"instance_id":"test-instance-id","binding_id":"other-binding-id","credentials":{"foo":"bar"}}`,
+			},
+			expectedErr: IDMismatchError{
+				Field:     "binding_id",
+				Requested: testBindingID,
+				Echoed:    "other-binding-id",
+			},
+		},
+		{
+			name: "200 with empty response",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   "",
+			},
+			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
+		{
+			name: "200 with empty response accepted",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   " \n",
+			},
+			acceptEmpty:      true,
+			expectedResponse: &GetBindingResponse{},
+		},
+		{
+			name: "200 with malformed response and empty responses accepted",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   malformedResponse,
+			},
+			acceptEmpty:        true,
+			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
 		{
 			name: "500 with malformed response",
 			httpReaction: httpReaction{
@@ -181,6 +228,10 @@ func TestGetBinding(t *testing.T) {
 
 		httpChecks := httpChecks{
 			URL: "/v2/service_instances/test-instance-id/service_bindings/test-binding-id",
+			params: map[string]string{
+				"service_id": tc.request.ServiceID,
+				"plan_id":    tc.request.PlanID,
+			},
 		}
 
 		if tc.APIVersion.label == "" {
@@ -189,8 +240,62 @@ func TestGetBinding(t *testing.T) {
 
 		klient := newTestClient(t, tc.name, tc.APIVersion, tc.enableAlpha, httpChecks, tc.httpReaction)
 
+		klient.AcceptEmptyGetResponses = tc.acceptEmpty
+
 		response, err := klient.GetBinding(tc.request)
 
 		doResponseChecks(t, tc.name, response, err, tc.expectedResponse, tc.expectedErrMessage, tc.expectedErr)
 	}
 }
+
+func TestGetBindingQueryParameters(t *testing.T) {
+	cases := []struct {
+		name          string
+		request       *GetBindingRequest
+		expectedQuery string
+	}{
+		{
+			name:          "service and plan IDs",
+			request:       defaultGetBindingRequest(),
+			expectedQuery: "plan_id=test-plan-id&service_id=test-service-id",
+		},
+		{
+			name: "service ID only",
+			request: &GetBindingRequest{
+				InstanceID: testInstanceID,
+				BindingID:  testBindingID,
+				ServiceID:  testServiceID,
+			},
+			expectedQuery: "service_id=test-service-id",
+		},
+		{
+			name: "no service or plan ID",
+			request: &GetBindingRequest{
+				InstanceID: testInstanceID,
+				BindingID:  testBindingID,
+			},
+			expectedQuery: "",
+		},
+	}
+
+	for _, tc := range cases {
+		var query string
+		klient := newTestClient(t, tc.name, LatestAPIVersion(), false, httpChecks{}, httpReaction{})
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			query = request.URL.RawQuery
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       closer(okBindingBytes),
+			}, nil
+		}
+
+		if _, err := klient.GetBinding(tc.request); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		if e, a := tc.expectedQuery, query; e != a {
+			t.Errorf("%v: unexpected query; expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
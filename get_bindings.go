@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// bindingsListInstanceIDPlaceholder stands for the instance ID in the
+// configured BindingsListPath.
+const bindingsListInstanceIDPlaceholder = "{instance_id}"
+
+func (c *client) GetBindings(instanceID string) ([]GetBindingResponse, error) {
+	if c.BindingsListPath == "" {
+		return nil, GetBindingsNotSupportedError{}
+	}
+
+	if instanceID == "" {
+		return nil, required("instanceID")
+	}
+
+	fullURL := c.URL + strings.ReplaceAll(c.BindingsListPath, bindingsListInstanceIDPlaceholder, url.PathEscape(instanceID))
+
+	response, err := c.prepareAndDo("GetBindings", http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = drainReader(response.Body)
+		response.Body.Close()
+	}()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		userResponse := []GetBindingResponse{}
+		if err := c.unmarshalResponse(response, &userResponse); err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		if !c.EnableAlphaFeatures {
+			for i := range userResponse {
+				userResponse[i].Endpoints = nil
+			}
+		}
+
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const testBindingsListPath = "/v2/service_instances/{instance_id}/service_bindings"
+
+const okBindingsBytes = `[` + okBindingBytes + `, ` + okBindingEndpointBytes + `]`
+
+func okGetBindingsResponse() []GetBindingResponse {
+	return []GetBindingResponse{*okGetBindingResponse(), *okGetBindingResponse()}
+}
+
+func TestGetBindings(t *testing.T) {
+	cases := []struct {
+		name               string
+		enableAlpha        bool
+		bindingsListPath   string
+		instanceID         string
+		httpReaction       httpReaction
+		expectedResponse   []GetBindingResponse
+		expectedErrMessage string
+		expectedErr        error
+	}{
+		{
+			name:             "not supported",
+			bindingsListPath: "-",
+			expectedErr:      GetBindingsNotSupportedError{},
+		},
+		{
+			name:               "missing instance ID",
+			instanceID:         "-",
+			expectedErrMessage: "instanceID is required",
+		},
+		{
+			name: "success",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   okBindingsBytes,
+			},
+			expectedResponse: okGetBindingsResponse(),
+		},
+		{
+			name:        "success with alpha features enabled",
+			enableAlpha: true,
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   okBindingsBytes,
+			},
+			expectedResponse: func() []GetBindingResponse {
+				r := okGetBindingsResponse()
+				r[1] = *okGetBindingEndpointResponse()
+				return r
+			}(),
+		},
+		{
+			name: "success with no bindings",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `[]`,
+			},
+			expectedResponse: []GetBindingResponse{},
+		},
+		{
+			name: "http error",
+			httpReaction: httpReaction{
+				err: fmt.Errorf("http error"),
+			},
+			expectedErrMessage: "http error",
+		},
+		{
+			name: "200 with malformed response",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   malformedResponse,
+			},
+			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
+		{
+			name: "500 with conventional failure response",
+			httpReaction: httpReaction{
+				status: http.StatusInternalServerError,
+				body:   conventionalFailureResponseBody,
+			},
+			expectedErr: testHTTPStatusCodeError(),
+		},
+	}
+
+	for _, tc := range cases {
+		switch tc.bindingsListPath {
+		case "":
+			tc.bindingsListPath = testBindingsListPath
+		case "-":
+			tc.bindingsListPath = ""
+		}
+
+		switch tc.instanceID {
+		case "":
+			tc.instanceID = testInstanceID
+		case "-":
+			tc.instanceID = ""
+		}
+
+		httpChecks := httpChecks{
+			URL: "/v2/service_instances/test-instance-id/service_bindings",
+		}
+
+		klient := newTestClient(t, tc.name, Version2_14(), tc.enableAlpha, httpChecks, tc.httpReaction)
+		klient.BindingsListPath = tc.bindingsListPath
+
+		response, err := klient.GetBindings(tc.instanceID)
+
+		doResponseChecks(t, tc.name, response, err, tc.expectedResponse, tc.expectedErrMessage, tc.expectedErr)
+	}
+}
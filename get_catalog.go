@@ -19,12 +19,14 @@ package v2
 import (
 	"fmt"
 	"net/http"
+
+	"k8s.io/klog/v2"
 )
 
 func (c *client) GetCatalog() (*CatalogResponse, error) {
 	fullURL := fmt.Sprintf(catalogURL, c.URL)
 
-	response, err := c.prepareAndDo(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	response, err := c.prepareAndDo("GetCatalog", http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +47,16 @@ func (c *client) GetCatalog() (*CatalogResponse, error) {
 			c.pruneCatalogResponse(catalogResponse)
 		}
 
+		catalogResponse.BrokerAPIVersion = response.Header.Get(APIVersionHeader)
+		catalogResponse.Warnings = responseWarnings(response)
+		if c.Verbose && isAPIVersionLabelLessThan(catalogResponse.BrokerAPIVersion, c.APIVersion.HeaderValue()) {
+			klog.Warningf("broker %q: advertises API version %s, lower than the client's API version %s", c.Name, catalogResponse.BrokerAPIVersion, c.APIVersion)
+		}
+
+		if c.ValidateParametersAgainstSchemas {
+			c.cacheSchemas(catalogResponse)
+		}
+
 		return catalogResponse, nil
 	default:
 		return nil, c.handleFailureResponse(response)
@@ -65,3 +77,102 @@ func (c *client) pruneCatalogResponse(catalogResponse *CatalogResponse) {
 		}
 	}
 }
+
+// isAPIVersionLabelLessThan returns whether the API version with the given
+// label, such as "2.13", is lower than the other one.  Labels need not be
+// versions known to this library; it returns false if either is malformed.
+func isAPIVersionLabelLessThan(label, other string) bool {
+	var major, minor, otherMajor, otherMinor int
+	if _, err := fmt.Sscanf(label, "%d.%d", &major, &minor); err != nil {
+		return false
+	}
+	if _, err := fmt.Sscanf(other, "%d.%d", &otherMajor, &otherMinor); err != nil {
+		return false
+	}
+	if major != otherMajor {
+		return major < otherMajor
+	}
+	return minor < otherMinor
+}
+
+// planKey identifies a plan of a service in a catalog.
+type planKey struct {
+	serviceID string
+	planID    string
+}
+
+// cacheSchemas replaces the plan schemas used for validation by copies of
+// those of the given catalog, which may be nil.
+func (c *client) cacheSchemas(catalog *CatalogResponse) {
+	var schemas map[planKey]*Schemas
+	if catalog != nil {
+		schemas = map[planKey]*Schemas{}
+		for _, service := range catalog.Services {
+			for _, plan := range service.Plans {
+				if plan.Schemas != nil {
+					schemas[planKey{serviceID: service.ID, planID: plan.ID}] = copySchemas(plan.Schemas)
+				}
+			}
+		}
+	}
+
+	holder := c.rootClient()
+	holder.catalogLock.Lock()
+	holder.schemas = schemas
+	holder.catalogLock.Unlock()
+}
+
+// planSchemas returns the schemas of the given plan of the given service in
+// the last fetched catalog, or nil if they are not known.
+func (c *client) planSchemas(serviceID, planID string) *Schemas {
+	holder := c.rootClient()
+	holder.catalogLock.RLock()
+	defer holder.catalogLock.RUnlock()
+
+	return holder.schemas[planKey{serviceID: serviceID, planID: planID}]
+}
+
+// copySchemas returns a deep copy of the given schemas.
+func copySchemas(schemas *Schemas) *Schemas {
+	copied := &Schemas{}
+	if instance := schemas.ServiceInstance; instance != nil {
+		copied.ServiceInstance = &ServiceInstanceSchema{
+			Create: copyInputParametersSchema(instance.Create),
+			Update: copyInputParametersSchema(instance.Update),
+		}
+	}
+	if binding := schemas.ServiceBinding; binding != nil {
+		copied.ServiceBinding = &ServiceBindingSchema{
+			Create: copyInputParametersSchema(binding.Create),
+		}
+	}
+	return copied
+}
+
+func copyInputParametersSchema(schema *InputParametersSchema) *InputParametersSchema {
+	if schema == nil {
+		return nil
+	}
+	return &InputParametersSchema{Parameters: copyJSONValue(schema.Parameters)}
+}
+
+// copyJSONValue returns a deep copy of a value decoded by encoding/json into
+// an interface{}.
+func copyJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			copied[k] = copyJSONValue(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, v := range value {
+			copied[i] = copyJSONValue(v)
+		}
+		return copied
+	default:
+		return value
+	}
+}
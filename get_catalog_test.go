@@ -17,9 +17,15 @@ limitations under the License.
 package v2
 
 import (
+	"bytes"
+	"flag"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+
+	"k8s.io/klog/v2"
 )
 
 const okCatalogBytes = `{
@@ -248,6 +254,48 @@ func okCatalog215Response() *CatalogResponse {
 	return response
 }
 
+const okCatalogWithMetadataBytes = `{
+  "services": [{
+    "name": "fake-service",
+    "id": "fake-service-id",
+    "description": "fake service",
+    "bindable": true,
+    "plans": [{
+      "name": "fake-plan",
+      "id": "fake-plan-id",
+      "description": "fake plan"
+    }]
+  }],
+  "metadata": {
+    "version": "42",
+    "updated_at": "2021-01-23T23:12:00Z"
+  }
+}`
+
+func okCatalogWithMetadataResponse() *CatalogResponse {
+	return &CatalogResponse{
+		Services: []Service{
+			{
+				Name:        "fake-service",
+				ID:          "fake-service-id",
+				Description: "fake service",
+				Bindable:    true,
+				Plans: []Plan{
+					{
+						Name:        "fake-plan",
+						ID:          "fake-plan-id",
+						Description: "fake plan",
+					},
+				},
+			},
+		},
+		Metadata: map[string]interface{}{
+			"version":    "42",
+			"updated_at": "2021-01-23T23:12:00Z",
+		},
+	}
+}
+
 func TestGetCatalog(t *testing.T) {
 	cases := []struct {
 		name               string
@@ -274,6 +322,42 @@ func TestGetCatalog(t *testing.T) {
 			},
 			expectedResponse: okCatalog2Response(),
 		},
+		{
+			name:    "success with broker API version header",
+			version: Version2_13(),
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   okCatalogBytes,
+				header: apiVersionHeader("2.14"),
+			},
+			expectedResponse: func() *CatalogResponse {
+				r := okCatalogResponse()
+				r.BrokerAPIVersion = "2.14"
+				return r
+			}(),
+		},
+		{
+			name:    "success with lower broker API version header",
+			version: Version2_13(),
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   okCatalogBytes,
+				header: apiVersionHeader("2.12"),
+			},
+			expectedResponse: func() *CatalogResponse {
+				r := okCatalogResponse()
+				r.BrokerAPIVersion = "2.12"
+				return r
+			}(),
+		},
+		{
+			name: "success with top-level metadata",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   okCatalogWithMetadataBytes,
+			},
+			expectedResponse: okCatalogWithMetadataResponse(),
+		},
 		{
 			name: "http error",
 			httpReaction: httpReaction{
@@ -361,3 +445,90 @@ func TestGetCatalog(t *testing.T) {
 		doResponseChecks(t, tc.name, response, err, tc.expectedResponse, tc.expectedErrMessage, tc.expectedErr)
 	}
 }
+
+func apiVersionHeader(version string) http.Header {
+	header := http.Header{}
+	header.Set(APIVersionHeader, version)
+	return header
+}
+
+func TestGetCatalogLowerBrokerAPIVersionWarning(t *testing.T) {
+	var logs bytes.Buffer
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	if err := flags.Set("logtostderr", "false"); err != nil {
+		t.Fatal(err)
+	}
+	klog.SetOutput(&logs)
+	defer flags.Set("logtostderr", "true")
+
+	for _, verbose := range []bool{false, true} {
+		logs.Reset()
+
+		klient := newTestClient(t, "lower broker API version", Version2_13(), false, httpChecks{}, httpReaction{
+			status: http.StatusOK,
+			body:   okCatalogBytes,
+			header: apiVersionHeader("2.12"),
+		})
+		klient.Verbose = verbose
+
+		response, err := klient.GetCatalog()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		klog.Flush()
+
+		if e, a := "2.12", response.BrokerAPIVersion; e != a {
+			t.Errorf("verbose %v: expected broker API version %q, got %q", verbose, e, a)
+		}
+		if e, a := verbose, strings.Contains(logs.String(), "advertises API version 2.12"); e != a {
+			t.Errorf("verbose %v: expected warning logged to be %v, logs: %q", verbose, e, logs.String())
+		}
+	}
+}
+
+func TestGetCatalogCachesCopiesOfSchemas(t *testing.T) {
+	klient := newTestClient(t, "cached schemas", Version2_13(), false, httpChecks{}, httpReaction{
+		status: http.StatusOK,
+		body:   schemaCatalogBytes,
+	})
+	klient.ValidateParametersAgainstSchemas = true
+
+	catalog, err := klient.GetCatalog()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	service := catalog.Services[0]
+	plan := &service.Plans[0]
+	plan.Schemas.ServiceBinding.Create.Parameters.(map[string]interface{})["zoo"] = "modified"
+	plan.Schemas = nil
+
+	expected := schemaCatalogResponse().Services[0].Plans[0].Schemas
+	if e, a := expected, klient.planSchemas(service.ID, plan.ID); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the cached schemas not to follow changes to the returned catalog; expected %+v, got %+v", e, a)
+	}
+}
+
+func TestIsAPIVersionLabelLessThan(t *testing.T) {
+	cases := []struct {
+		label    string
+		other    string
+		expected bool
+	}{
+		{label: "2.12", other: "2.13", expected: true},
+		{label: "2.13", other: "2.13", expected: false},
+		{label: "2.14", other: "2.13", expected: false},
+		{label: "2.9", other: "2.13", expected: true},
+		{label: "2.18", other: "2.17", expected: false},
+		{label: "1.99", other: "2.11", expected: true},
+		{label: "", other: "2.13", expected: false},
+		{label: "latest", other: "2.13", expected: false},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, isAPIVersionLabelLessThan(tc.label, tc.other); e != a {
+			t.Errorf("%q < %q: expected %v, got %v", tc.label, tc.other, e, a)
+		}
+	}
+}
@@ -35,7 +35,7 @@ func (c *client) GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error
 		"plan_id":    r.PlanID,
 	}
 
-	response, err := c.prepareAndDo(http.MethodGet, fullURL, params, nil /* request body */, nil /* originating identity */)
+	response, err := c.prepareAndDo("GetInstance", http.MethodGet, fullURL, params, nil /* request body */, nil /* originating identity */)
 	if err != nil {
 		return nil, err
 	}
@@ -48,9 +48,22 @@ func (c *client) GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error
 	switch response.StatusCode {
 	case http.StatusOK:
 		userResponse := &GetInstanceResponse{}
-		if err := c.unmarshalResponse(response, userResponse); err != nil {
+		body := struct {
+			*GetInstanceResponse
+			echoedIDs
+			DashboardURL dashboardField `json:"dashboard_url"`
+		}{GetInstanceResponse: userResponse}
+		if err := c.unmarshalGetResponse(response, &body); err != nil {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
+		if err := body.verify(r.InstanceID, ""); err != nil {
+			return nil, err
+		}
+		if body.DashboardURL.URL != nil {
+			userResponse.DashboardURL = *body.DashboardURL.URL
+		}
+		userResponse.Dashboards = body.DashboardURL.Dashboards
+		userResponse.Warnings = responseWarnings(response)
 
 		return userResponse, nil
 	default:
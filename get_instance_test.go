@@ -60,6 +60,7 @@ func TestGetInstance(t *testing.T) {
 		expectedResponse   *GetInstanceResponse
 		expectedErrMessage string
 		expectedErr        error
+		acceptEmpty        bool
 	}{
 		{
 			name: "success",
@@ -84,6 +85,80 @@ func TestGetInstance(t *testing.T) {
 			},
 			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
 		},
+		{
+			name: "200 with matching echoed instance ID",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"instance_id":"test-instance-id","service_id":"test-service","plan_id":"test-plan"}`,
+			},
+			expectedResponse: okGetInstanceResponse(),
+		},
+		{
+			name: "200 with dashboard URL",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"service_id":"test-service","plan_id":"test-plan","dashboard_url":"https://example.com/dashboard"}`,
+			},
+			expectedResponse: func() *GetInstanceResponse {
+				r := okGetInstanceResponse()
+				r.DashboardURL = "https://example.com/dashboard"
+				return r
+			}(),
+		},
+		{
+			name: "200 with dashboard list",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"service_id":"test-service","plan_id":"test-plan","dashboard_url":[{"url":"https://example.com/a","label":"A"},"https://example.com/b"]}`,
+			},
+			expectedResponse: func() *GetInstanceResponse {
+				r := okGetInstanceResponse()
+				r.DashboardURL = "https://example.com/a"
+				r.Dashboards = []Dashboard{
+					{URL: "https://example.com/a", Label: "A"},
+					{URL: "https://example.com/b"},
+				}
+				return r
+			}(),
+		},
+		{
+			name: "200 with mismatched echoed instance ID",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"instance_id":"other-instance-id","service_id":"test-service","plan_id":"test-plan"}`,
+			},
+			expectedErr: IDMismatchError{
+				Field:     "instance_id",
+				Requested: testInstanceID,
+				Echoed:    "other-instance-id",
+			},
+		},
+		{
+			name: "200 with empty response",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   "",
+			},
+			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
+		{
+			name: "200 with empty response accepted",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   " \n",
+			},
+			acceptEmpty:      true,
+			expectedResponse: &GetInstanceResponse{},
+		},
+		{
+			name: "200 with malformed response and empty responses accepted",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   malformedResponse,
+			},
+			acceptEmpty:        true,
+			expectedErrMessage: "Status: 200; ErrorMessage: <nil>; Description: <nil>; ResponseError: unexpected end of JSON input",
+		},
 		{
 			name: "500 with malformed response",
 			httpReaction: httpReaction{
@@ -122,6 +197,8 @@ func TestGetInstance(t *testing.T) {
 
 		klient := newTestClient(t, tc.name, tc.APIVersion, tc.enableAlpha, httpChecks, tc.httpReaction)
 
+		klient.AcceptEmptyGetResponses = tc.acceptEmpty
+
 		response, err := klient.GetInstance(tc.request)
 
 		doResponseChecks(t, tc.name, response, err, tc.expectedResponse, tc.expectedErrMessage, tc.expectedErr)
@@ -24,7 +24,7 @@ import (
 func (c *client) GetStatus() (*GetStatusResponse, error) {
 	fullURL := fmt.Sprintf(statusURL, c.URL)
 
-	response, err := c.prepareAndDo(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	response, err := c.prepareAndDo("GetStatus", http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +40,7 @@ func (c *client) GetStatus() (*GetStatusResponse, error) {
 		if err := c.unmarshalResponse(response, statusResponse); err != nil {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
+		statusResponse.Warnings = responseWarnings(response)
 
 		return statusResponse, nil
 	default:
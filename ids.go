@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxIDLength is the maximum length, in bytes, accepted by ValidateID.
+	maxIDLength = 255
+	// maxLabelLength is the maximum length of a Kubernetes label value.
+	maxLabelLength = 63
+	// labelHashLength is the number of hexadecimal characters of the hash
+	// suffixed to labels that had to be shortened.
+	labelHashLength = 10
+)
+
+// ValidateID checks an ID assigned by a broker, such as a service or plan
+// ID.  The Open Service Broker API treats these IDs as opaque, so the rules
+// are lenient and only reject IDs that platforms cannot reasonably store:
+//
+//   - the ID must not be empty
+//   - the ID must not contain whitespace or control characters
+//   - the ID must not be longer than 255 bytes
+func ValidateID(id string) error {
+	if id == "" {
+		return errors.New("ID must not be empty")
+	}
+	if len(id) > maxIDLength {
+		return fmt.Errorf("ID must not be longer than %d bytes, got %d", maxIDLength, len(id))
+	}
+	for _, r := range id {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return fmt.Errorf("ID must not contain whitespace or control characters: %q", id)
+		}
+	}
+	return nil
+}
+
+// SanitizeForLabel derives a valid Kubernetes label value from an ID
+// assigned by a broker, so that platforms deriving resource names or labels
+// from broker IDs do so consistently:
+//
+//   - characters other than ASCII letters, digits, '-', '_' and '.' are
+//     replaced with '-'
+//   - leading and trailing characters that are not letters or digits are
+//     removed
+//   - values longer than 63 characters are shortened and suffixed with a
+//     hash of the whole ID, so that distinct long IDs keep distinct labels
+//
+// IDs that are already valid label values are returned unchanged.
+func SanitizeForLabel(id string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if isLabelAlphanumeric(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, id)
+	sanitized = trimToLabelAlphanumeric(sanitized)
+
+	if len(sanitized) <= maxLabelLength {
+		return sanitized
+	}
+
+	sum := sha256.Sum256([]byte(id))
+	hash := hex.EncodeToString(sum[:])[:labelHashLength]
+	prefix := trimToLabelAlphanumeric(sanitized[:maxLabelLength-labelHashLength-1])
+	return prefix + "-" + hash
+}
+
+func isLabelAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func trimToLabelAlphanumeric(s string) string {
+	return strings.TrimFunc(s, func(r rune) bool { return !isLabelAlphanumeric(r) })
+}
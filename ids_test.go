@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidateID(t *testing.T) {
+	cases := []struct {
+		name  string
+		id    string
+		valid bool
+	}{
+		{name: "uuid", id: "acb56d7c-XXXX-XXXX-XXXX-feb140a59a66", valid: true},
+		{name: "arbitrary characters", id: "my/service:v1.2", valid: true},
+		{name: "empty", id: ""},
+		{name: "space", id: "my service"},
+		{name: "tab", id: "my\tservice"},
+		{name: "control character", id: "my\x00service"},
+		{name: "maximum length", id: strings.Repeat("a", 255), valid: true},
+		{name: "too long", id: strings.Repeat("a", 256)},
+	}
+
+	for _, tc := range cases {
+		err := ValidateID(tc.id)
+		if err != nil {
+			if tc.valid {
+				t.Errorf("%v: expected valid, got error: %v", tc.name, err)
+			}
+		} else if !tc.valid {
+			t.Errorf("%v: expected invalid, got valid", tc.name)
+		}
+	}
+}
+
+var labelValueRegexp = regexp.MustCompile(`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+
+func TestSanitizeForLabel(t *testing.T) {
+	cases := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{name: "valid label", id: "acb56d7c-XXXX-XXXX-XXXX-feb140a59a66", expected: "acb56d7c-XXXX-XXXX-XXXX-feb140a59a66"},
+		{name: "invalid characters", id: "my/service:v1.2", expected: "my-service-v1.2"},
+		{name: "leading and trailing symbols", id: "_-my-service.-", expected: "my-service"},
+		{name: "only symbols", id: "///", expected: ""},
+		{name: "empty", id: "", expected: ""},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, SanitizeForLabel(tc.id); e != a {
+			t.Errorf("%v: expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func TestSanitizeForLabelLongIDs(t *testing.T) {
+	first := SanitizeForLabel(strings.Repeat("a", 100) + "1")
+	second := SanitizeForLabel(strings.Repeat("a", 100) + "2")
+
+	for _, label := range []string{first, second} {
+		if len(label) > 63 {
+			t.Errorf("expected at most 63 characters, got %d: %q", len(label), label)
+		}
+		if !labelValueRegexp.MatchString(label) {
+			t.Errorf("expected a valid label value, got %q", label)
+		}
+	}
+	if first == second {
+		t.Errorf("expected distinct long IDs to keep distinct labels, got %q for both", first)
+	}
+	if e, a := first, SanitizeForLabel(strings.Repeat("a", 100)+"1"); e != a {
+		t.Errorf("expected sanitizing to be deterministic; expected %q, got %q", e, a)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+)
+
+func (c *client) InstanceExists(ctx context.Context, instanceID string) (bool, error) {
+	if err := c.validateClientVersionIsAtLeast(Version2_14()); err != nil {
+		return false, GetInstanceNotAllowedError{
+			reason: err.Error(),
+		}
+	}
+
+	if instanceID == "" {
+		return false, required("instanceID")
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.URL, instanceID)
+
+	exists, err := c.instanceExists(ctx, http.MethodHead, fullURL)
+	if err != nil && isHeadUnsupported(err) {
+		if c.Verbose {
+			klog.Infof("broker %q: HEAD not supported, falling back to GET for instance %q", c.Name, instanceID)
+		}
+		return c.instanceExists(ctx, http.MethodGet, fullURL)
+	}
+
+	return exists, err
+}
+
+// instanceExists issues a request with the given method and interprets its
+// status code; the response body, if any, is discarded.
+func (c *client) instanceExists(ctx context.Context, method, fullURL string) (bool, error) {
+	response, err := c.prepareAndDoWithContext(ctx, "InstanceExists", method, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		_ = drainReader(response.Body)
+		response.Body.Close()
+	}()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		if method == http.MethodHead {
+			// The body of a HEAD response is always empty, so there is
+			// no failure response to decode.
+			return false, HTTPStatusCodeError{StatusCode: response.StatusCode}
+		}
+		return false, c.handleFailureResponse(response)
+	}
+}
+
+// isHeadUnsupported returns whether the given error indicates that the
+// broker does not route HEAD requests for instances.
+func isHeadUnsupported(err error) bool {
+	statusCodeErr, ok := err.(HTTPStatusCodeError)
+	if !ok {
+		return false
+	}
+
+	return statusCodeErr.StatusCode == http.StatusMethodNotAllowed ||
+		statusCodeErr.StatusCode == http.StatusNotImplemented
+}
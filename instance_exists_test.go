@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestInstanceExists(t *testing.T) {
+	cases := []struct {
+		name               string
+		version            APIVersion
+		instanceID         string
+		reactions          map[string]httpReaction
+		expectedMethods    []string
+		expectedExists     bool
+		expectedErrMessage string
+	}{
+		{
+			name:               "unsupported API version",
+			version:            Version2_13(),
+			expectedErrMessage: "GetInstance not allowed: operation not allowed: must have API version >= 2.14. Current: 2.13",
+		},
+		{
+			name:               "missing instance ID",
+			instanceID:         "-",
+			expectedErrMessage: "instanceID is required",
+		},
+		{
+			name: "HEAD 200",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {status: http.StatusOK},
+			},
+			expectedMethods: []string{http.MethodHead},
+			expectedExists:  true,
+		},
+		{
+			name: "HEAD 404",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {status: http.StatusNotFound},
+			},
+			expectedMethods: []string{http.MethodHead},
+		},
+		{
+			name: "HEAD 500",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {status: http.StatusInternalServerError},
+			},
+			expectedMethods:    []string{http.MethodHead},
+			expectedErrMessage: "Status: 500; ErrorMessage: <nil>; Description: <nil>; ResponseError: <nil>",
+		},
+		{
+			name: "HEAD 405 falls back to GET 200",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {status: http.StatusMethodNotAllowed},
+				http.MethodGet:  {status: http.StatusOK, body: okInstanceBytes},
+			},
+			expectedMethods: []string{http.MethodHead, http.MethodGet},
+			expectedExists:  true,
+		},
+		{
+			name: "HEAD 501 falls back to GET 404",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {status: http.StatusNotImplemented},
+				http.MethodGet:  {status: http.StatusNotFound, body: "{}"},
+			},
+			expectedMethods: []string{http.MethodHead, http.MethodGet},
+		},
+		{
+			name: "HEAD 405 falls back to GET 500",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {status: http.StatusMethodNotAllowed},
+				http.MethodGet:  {status: http.StatusInternalServerError, body: conventionalFailureResponseBody},
+			},
+			expectedMethods:    []string{http.MethodHead, http.MethodGet},
+			expectedErrMessage: testHTTPStatusCodeError().Error(),
+		},
+		{
+			name: "http error",
+			reactions: map[string]httpReaction{
+				http.MethodHead: {err: fmt.Errorf("http error")},
+			},
+			expectedMethods:    []string{http.MethodHead},
+			expectedErrMessage: "http error",
+		},
+	}
+
+	for _, tc := range cases {
+		if tc.version.label == "" {
+			tc.version = Version2_14()
+		}
+
+		switch tc.instanceID {
+		case "":
+			tc.instanceID = testInstanceID
+		case "-":
+			tc.instanceID = ""
+		}
+
+		var methods []string
+		klient := newTestClient(t, tc.name, tc.version, false, httpChecks{}, httpReaction{})
+		klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+			methods = append(methods, request.Method)
+
+			if e, a := "/v2/service_instances/"+testInstanceID, request.URL.Path; e != a {
+				t.Errorf("%v: unexpected URL; expected %v, got %v", tc.name, e, a)
+			}
+
+			reaction, ok := tc.reactions[request.Method]
+			if !ok {
+				t.Errorf("%v: unexpected %v request", tc.name, request.Method)
+				return nil, errWalkingGhost
+			}
+			if reaction.err != nil {
+				return nil, reaction.err
+			}
+
+			return &http.Response{
+				StatusCode: reaction.status,
+				Body:       closer(reaction.body),
+			}, nil
+		}
+
+		exists, err := klient.InstanceExists(context.Background(), tc.instanceID)
+
+		if e, a := tc.expectedExists, exists; e != a {
+			t.Errorf("%v: unexpected result; expected %v, got %v", tc.name, e, a)
+		}
+
+		if tc.expectedErrMessage == "" && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		} else if tc.expectedErrMessage != "" && (err == nil || err.Error() != tc.expectedErrMessage) {
+			t.Errorf("%v: unexpected error; expected %q, got %v", tc.name, tc.expectedErrMessage, err)
+		}
+
+		if e, a := tc.expectedMethods, methods; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected methods; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestInstanceExistsCanceledContext(t *testing.T) {
+	klient := newTestClient(t, "canceled context", Version2_14(), false, httpChecks{}, httpReaction{})
+	klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+		if err := request.Context().Err(); err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: closer("")}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := klient.InstanceExists(ctx, testInstanceID); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestInstanceExistsCanceledContextOverHTTP(t *testing.T) {
+	requestReceived := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestReceived)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	config := DefaultClientConfiguration()
+	config.URL = server.URL
+	config.APIVersion = Version2_14()
+	klient, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requestReceived
+		cancel()
+	}()
+
+	_, err = klient.(InstanceChecker).InstanceExists(ctx, testInstanceID)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected an error matching context.Canceled, got %v", err)
+	}
+	if _, ok := IsHTTPError(err); ok {
+		t.Errorf("expected the error not to be an HTTPStatusCodeError, got %v", err)
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package v2
 
 import (
+	"context"
 	"crypto/tls"
+	"net/http"
+	"time"
 )
 
 // AuthConfig is a union-type representing the possible auth configurations a
@@ -80,21 +83,220 @@ type ClientConfiguration struct {
 	// CAData holds PEM-encoded bytes (typically read from a root certificates bundle).
 	// This CA certificate will be added to any specified in TLSConfig.RootCAs.
 	CAData []byte
+	// TLSRenegotiation sets the Renegotiation field of the TLS configuration,
+	// for legacy brokers, or load balancers in front of them, that require
+	// TLS renegotiation.  If set, it overrides the value in the TLSConfig
+	// field.  Renegotiation is disabled by default because it weakens TLS:
+	// it has been the source of several attacks, is not supported by TLS
+	// 1.3, and a renegotiated connection may present a different server
+	// certificate than the one verified initially.  Prefer
+	// tls.RenegotiateOnceAsClient over tls.RenegotiateFreelyAsClient.
+	TLSRenegotiation tls.RenegotiationSupport
 	// Verbose is whether the client will log to klog.
 	Verbose bool
+	// RequireLastOperationIDs controls whether the client requires the
+	// ServiceID and PlanID fields to be set on last operation requests.  Some
+	// brokers reject last operation requests without these IDs; enabling
+	// this surfaces the problem before a request is sent.
+	RequireLastOperationIDs bool
+	// RequestIdentityVerification controls what the client does when a
+	// broker echoes an X-Broker-API-Request-Identity header that differs from
+	// the one sent with the request.  Defaults to no verification.  The sent
+	// and received identities are only exposed, in a ResponseMeta, by the
+	// RequestIdentityMismatchError of the strict mode; the warn mode only
+	// logs them.
+	RequestIdentityVerification RequestIdentityVerification
+	// FollowRedirects controls whether the client follows redirects to a
+	// different host than the one of the original request.  When set, the
+	// client re-attaches its auth configuration to the redirected request,
+	// since it would otherwise be stripped.  When unset, such redirects
+	// are refused with an error.  Redirects to the same host are always
+	// followed.
+	FollowRedirects bool
+	// GenerateInstanceIDs controls whether ProvisionInstance generates a
+	// GUID, as recommended by the Open Service Broker API specification, for
+	// requests with an empty InstanceID.  The generated ID is returned in the
+	// InstanceID of the ProvisionResponse; the request is left unchanged.
+	// When unset, an empty InstanceID is an
+	// error.
+	GenerateInstanceIDs bool
+	// AcceptOperationKeyAliases controls whether the client accepts the
+	// operation key of an asynchronous response under names other than the
+	// 'operation' field defined by the specification: an 'operation_key'
+	// field, or an 'operation' object with a 'key' field.  Some brokers
+	// predating the finalized field name use these.  Disabled by default so
+	// that malformed responses are not masked.
+	AcceptOperationKeyAliases bool
+	// MaxClockSkew bounds how far the Date header of a broker response may
+	// differ from the client's clock when it is used as the reference time
+	// for Retry-After HTTP dates.  Dates further off are clamped, so that a
+	// wildly wrong broker clock cannot produce absurd delays.  Zero disables
+	// the clamp.
+	MaxClockSkew time.Duration
+	// ValidateParametersAgainstSchemas controls whether the client validates
+	// the parameters of bind requests against the binding create schema of
+	// the requested plan, using ValidateParameters, before sending them.  The
+	// schema is taken from the catalog most recently returned by GetCatalog;
+	// if the catalog has not been fetched or the plan declares no schema, no
+	// validation happens.  Binding rotation carries no parameters and is
+	// therefore never validated.
+	ValidateParametersAgainstSchemas bool
+	// MaxLogBodyBytes caps how many bytes of a response body are logged in
+	// verbose mode; longer bodies are truncated in the log, with their total
+	// length, but always unmarshaled in full.  Zero means no truncation.
+	// DefaultClientConfiguration sets it to 4096.
+	MaxLogBodyBytes int
+	// BindingsListPath is the path, relative to URL, of the non-standard
+	// endpoint some brokers expose to list the bindings of an instance, with
+	// {instance_id} standing for the instance ID; for example
+	// "/v2/service_instances/{instance_id}/service_bindings".  It is used by
+	// GetBindings, which is not supported when it is empty.
+	BindingsListPath string
+	// SignRequest, if set, is called with each request once it is fully
+	// built, with all headers, query parameters and body set, just before it
+	// is sent; for example to sign it for a gateway using AWS SigV4.  The
+	// body can be read again with the GetBody field of the request.  If
+	// SignRequest returns an error, the request is not sent and the error is
+	// returned as is.  Requests following a redirect are not signed again.
+	SignRequest func(*http.Request) error
+	// WarnOnDeprecatedFields controls whether the client logs a warning,
+	// suggesting the replacement, when a request sets a deprecated field such
+	// as BindRequest.AppGUID or PreviousValues.OrgID.
+	WarnOnDeprecatedFields bool
+	// AlwaysAcceptIncomplete forces provision, update, deprovision, bind,
+	// unbind and rotate binding requests to be sent with
+	// accepts_incomplete=true, whatever their AcceptsIncomplete field, for
+	// platforms that always support asynchronous operations and talk to
+	// brokers that reject synchronous requests with AsyncRequired.  Binding
+	// requests are only forced with API version >= 2.14, since asynchronous
+	// binding operations require it.
+	AlwaysAcceptIncomplete bool
+	// UseJSONNumbers controls whether numbers in the free-form maps of broker
+	// responses, such as Credentials, instance Parameters and catalog
+	// Metadata, are unmarshaled as json.Number instead of float64, which
+	// cannot represent integers above 2^53 exactly.  Callers then read them
+	// with the Int64, Float64 or String methods of json.Number, for example
+	// response.Credentials["port"].(json.Number).Int64().  Fields with a
+	// concrete numeric type are not affected.
+	UseJSONNumbers bool
+	// DefaultContext holds context keys, such as the platform, organization
+	// and space, merged into the Context of every provision, update and bind
+	// request, including requests without a Context.  Keys set by the
+	// request take precedence.  Like the Context of requests, it is only sent
+	// with the API versions supporting it, and is never modified.
+	DefaultContext map[string]interface{}
+	// TransformRequestBody, if set, is called with the marshaled JSON body
+	// of each request having one, and returns the bytes to send instead; for
+	// example to wrap the standard body in the envelope expected by a proxy.
+	// operation is the name of the Client method the request is made for,
+	// such as "ProvisionInstance" or "Bind".  The Content-Type of the request
+	// stays application/json.  If it returns an error, the request is not
+	// sent and the error is returned as is.
+	TransformRequestBody func(operation string, body []byte) ([]byte, error)
+	// CorrelationIDHeader is the name of a header, such as
+	// "X-Correlation-ID", carrying an ID with which some brokers join their
+	// logs with those of the platform across services.  It is distinct from
+	// the X-Broker-API-Request-Identity header.  No correlation header is
+	// sent unless both CorrelationIDHeader and CorrelationID are set.
+	CorrelationIDHeader string
+	// CorrelationID returns the value of the CorrelationIDHeader header for
+	// the given request, or an empty string to send none.  It is called
+	// before SignRequest.  The context of the request is the one passed to
+	// the methods taking one, such as InstanceExists, so that with
+	// OpenTelemetry, for example, it can return the trace ID of
+	// trace.SpanContextFromContext(request.Context()).
+	CorrelationID func(*http.Request) string
+	// AcceptEmptyGetResponses controls whether a 200 response with an empty
+	// body to GetInstance or GetBinding is returned as a zero-valued
+	// response, for brokers meaning "exists, without details" by it, rather
+	// than as an HTTPStatusCodeError.  Disabled by default, so that such
+	// responses are rejected like other malformed ones.
+	AcceptEmptyGetResponses bool
+	// SlowRequestThreshold, if positive, is the round trip time over which
+	// a warning naming the operation and its elapsed time is logged, to
+	// surface degraded brokers without collecting metrics.
+	SlowRequestThreshold time.Duration
+	// MaxConcurrentRequests, if positive, is the maximum number of requests
+	// the client has in flight at once, to protect fragile brokers.  Further
+	// requests wait for one in flight to complete, until their context is
+	// done.  A request is in flight until its response body is closed.
+	MaxConcurrentRequests int
+	// MaxOriginatingIdentityBytes caps the length of the originating
+	// identity header value, base64-encoded identity included.  Requests
+	// with a longer one fail before being sent, rather than being rejected
+	// by the broker or a proxy with an obscure 431, so that the caller can
+	// trim the identity, such as the groups or extra data of a Kubernetes
+	// user.  Zero means no limit.  DefaultClientConfiguration sets it to
+	// 8192.
+	MaxOriginatingIdentityBytes int
+	// ErrorBodyExcerptBytes, if positive, includes up to that many bytes of
+	// a response body that cannot be unmarshalled in the resulting error, as
+	// a ResponseUnmarshalError, to show what the broker returned without
+	// enabling verbose logging.  The values of fields named like secrets,
+	// such as passwords and tokens, and the userinfo of URLs are redacted.
+	// Zero, the default, leaves the body out.
+	ErrorBodyExcerptBytes int
+	// BodyReadTimeout, if positive, bounds the time spent reading a response
+	// body once the broker has sent its headers, so that a broker dribbling
+	// the body slowly fails fast instead of consuming the whole Timeout.
+	// Exceeding it fails the operation with a BodyReadTimeoutError as the
+	// ResponseError of an HTTPStatusCodeError, unlike connection timeouts.
+	BodyReadTimeout time.Duration
+	// DryRun makes the client validate and build every request in full,
+	// signing included, but never send it: each operation instead gets a
+	// canned success response, such as an empty catalog, a synchronous
+	// provision or a succeeded last operation.  It lets tests exercise the
+	// whole client code without a live broker.
+	DryRun bool
+	// DiscoveryPath is the path, relative to URL, of the non-standard
+	// discovery document some brokers expose to describe the API versions,
+	// extensions and authentication methods they support, such as
+	// "/v2/discovery".  It enables Discover, which is not supported when it
+	// is empty.
+	DiscoveryPath string
 }
 
+// RequestIdentityVerification is a typedef representing how the client
+// verifies the request identity echoed by a broker.
+type RequestIdentityVerification string
+
+// These are the supported request identity verification modes.  A response
+// without a request identity header is never considered a mismatch.
+const (
+	// RequestIdentityVerificationNone disables verification.
+	RequestIdentityVerificationNone RequestIdentityVerification = ""
+	// RequestIdentityVerificationWarn logs a warning on mismatch, with the
+	// sent and received identities, and otherwise handles the response as
+	// usual.  Nothing about the mismatch is returned to the caller.
+	RequestIdentityVerificationWarn RequestIdentityVerification = "warn"
+	// RequestIdentityVerificationStrict returns a
+	// RequestIdentityMismatchError on mismatch.
+	RequestIdentityVerificationStrict RequestIdentityVerification = "strict"
+)
+
+// defaultMaxLogBodyBytes is the default value of
+// ClientConfiguration.MaxLogBodyBytes.
+const defaultMaxLogBodyBytes = 4096
+
+// defaultMaxOriginatingIdentityBytes is the default value of
+// ClientConfiguration.MaxOriginatingIdentityBytes.
+const defaultMaxOriginatingIdentityBytes = 8192
+
 // DefaultClientConfiguration returns a default ClientConfiguration:
 //
 //   - latest API version
 //   - 60 second timeout (referenced as a typical timeout in the Open Service
 //     Broker API spec)
 //   - alpha features disabled
+//   - logged response bodies truncated to 4096 bytes
+//   - originating identity header values limited to 8192 bytes
 func DefaultClientConfiguration() *ClientConfiguration {
 	return &ClientConfiguration{
-		APIVersion:          LatestAPIVersion(),
-		TimeoutSeconds:      60,
-		EnableAlphaFeatures: false,
+		APIVersion:                  LatestAPIVersion(),
+		TimeoutSeconds:              60,
+		EnableAlphaFeatures:         false,
+		MaxLogBodyBytes:             defaultMaxLogBodyBytes,
+		MaxOriginatingIdentityBytes: defaultMaxOriginatingIdentityBytes,
 	}
 }
 
@@ -111,16 +313,44 @@ func DefaultClientConfiguration() *ClientConfiguration {
 //
 // 1.  Create a new binding to an instance of a service with the Bind method
 // 2.  Delete a binding to an instance with the Unbind method
+//
+// Client is composed of smaller interfaces grouping related operations, so
+// that callers which only use some operations can depend on, and fake, just
+// those.
+//
+// A Client is safe for concurrent use. It never modifies the maps carried by
+// requests, such as Parameters and Context, so requests sent concurrently
+// may share them, nor any other field of a request.
 type Client interface {
+	CatalogReader
+	Provisioner
+	Poller
+	Binder
+	StatusReader
+	ConfigurationReader
+}
+
+// CatalogReader is the subset of the Client interface for reading a
+// broker's catalog.
+type CatalogReader interface {
 	// GetCatalog returns information about the services the broker offers and
 	// their plans or an error.  GetCatalog calls GET on the Broker's catalog
 	// endpoint (/v2/catalog).
 	GetCatalog() (*CatalogResponse, error)
+}
+
+// Provisioner is the subset of the Client interface for managing the
+// lifecycle of service instances.
+type Provisioner interface {
 	// ProvisionInstance requests that a new instance of a service be
 	// provisioned and returns information about the instance or an error.
 	// ProvisionInstance does a PUT on the Broker's endpoint for the requested
 	// instance ID (/v2/service_instances/instance-id).
 	//
+	// The spec uses '201 Created' for a new instance and '200 OK' for an
+	// identical instance that already exists; both are treated as success,
+	// since brokers do not always tell the two cases apart.
+	//
 	// If the AcceptsIncomplete field of the request is set to true, the
 	// broker may complete the request asynchronously.  Callers should check
 	// the value of the Async field on the response and check the operation
@@ -154,6 +384,31 @@ type Client interface {
 	// GetInstance calls GET on the Broker's endpoint for the requested
 	// instance ID (/v2/service_instances/instance-id)
 	GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error)
+}
+
+// InstanceChecker is implemented by clients that can check whether a broker
+// knows an instance without fetching it.  InstanceExists relies on HEAD
+// requests, which are not part of the Open Service Broker API, and is
+// therefore not part of Client.
+type InstanceChecker interface {
+	// InstanceExists requires a client API version >= 2.14.
+	//
+	// InstanceExists reports whether the broker knows the given instance ID,
+	// without decoding the instance itself. It issues a HEAD on the Broker's
+	// endpoint for the instance (/v2/service_instances/instance-id); a 200
+	// means the instance exists, a 404 means it does not, and any other
+	// status is returned as an error.
+	//
+	// HEAD is not part of the OSB specification, so brokers may not route
+	// it. If the broker answers the HEAD with 405 Method Not Allowed or 501
+	// Not Implemented, InstanceExists falls back to a GET on the same
+	// endpoint, interprets its status the same way, and discards the body.
+	InstanceExists(ctx context.Context, instanceID string) (bool, error)
+}
+
+// Poller is the subset of the Client interface for checking the status of
+// asynchronous operations.
+type Poller interface {
 	// PollLastOperation sends a request to query the last operation for a
 	// service instance to the broker and returns information about the
 	// operation or an error.  PollLastOperation does a GET on the broker's
@@ -185,10 +440,18 @@ type Client interface {
 	// an asynchronous unbind, callers should test the value of the returned
 	// error with IsGoneError.
 	PollBindingLastOperation(r *BindingLastOperationRequest) (*LastOperationResponse, error)
+}
+
+// Binder is the subset of the Client interface for managing the lifecycle
+// of service bindings.
+type Binder interface {
 	// Bind requests a new binding between a service instance and an
 	// application and returns information about the binding or an error. Bind
 	// does a PUT on the Broker's endpoint for the requested instance and
 	// binding IDs (/v2/service_instances/instance-id/service_bindings/binding-id).
+	//
+	// As for ProvisionInstance, '200 OK' and '201 Created' are both treated
+	// as success.
 	Bind(r *BindRequest) (*BindResponse, error)
 	// Unbind requests that a binding between a service instance and an
 	// application be deleted and returns information about the binding or an
@@ -205,10 +468,63 @@ type Client interface {
 	// RotateBinding requests the rotation of a binding's credentials.
 	// RotateBinding calls PUT on the Broker's binding endpoint
 	// (/v2/service_instances/instance-id/service_bindings/binding-id).
+	// '200 OK' and '201 Created' are both treated as success.
 	RotateBinding(r *RotateBindingRequest) (*BindResponse, error)
+}
+
+// BindingLister is implemented by clients that can list the bindings of an
+// instance.  GetBindings is an extension to the Open Service Broker API, for
+// brokers exposing a non-standard endpoint listing the bindings of an
+// instance, and is therefore not part of Client.
+type BindingLister interface {
+	// GetBindings returns the bindings of the given instance.  It calls GET
+	// on the path configured by ClientConfiguration.BindingsListPath and
+	// expects a JSON array of bindings in response.  If no path is
+	// configured, it returns a GetBindingsNotSupportedError without
+	// contacting the broker.
+	GetBindings(instanceID string) ([]GetBindingResponse, error)
+}
+
+// StatusReader is the subset of the Client interface for checking the
+// health of a broker.
+type StatusReader interface {
+	// GetStatus returns the status reported by the broker or an error.
+	// GetStatus calls GET on the Broker's status endpoint (/status).
 	GetStatus() (*GetStatusResponse, error)
 }
 
+// ConfigurationReader is the subset of the Client interface for reading back
+// how a client is configured, for example to log it or to display it.
+type ConfigurationReader interface {
+	// BrokerURL returns the URL of the broker the client talks to.
+	BrokerURL() string
+	// BrokerAPIVersion returns the API version the client uses to talk to
+	// the broker.
+	BrokerAPIVersion() APIVersion
+	// AlphaEnabled returns whether alpha features of the API are enabled.
+	AlphaEnabled() bool
+}
+
+// Discoverer is implemented by clients that can read the discovery document
+// of a broker.  Discover is an extension to the Open Service Broker API, for
+// brokers exposing such a document, and is therefore not part of Client.
+type Discoverer interface {
+	// Discover returns the capabilities of the broker.  It calls GET on the
+	// path configured by ClientConfiguration.DiscoveryPath.  If no path is
+	// configured, it returns a DiscoveryNotSupportedError without
+	// contacting the broker.
+	Discover(ctx context.Context) (*BrokerCapabilities, error)
+}
+
+// Refresher is implemented by clients whose connection settings can be
+// replaced in place, for example to pick up a rotated CA certificate or new
+// credentials without swapping the client references held by callers.
+type Refresher interface {
+	// Refresh rebuilds the transport of the client from the given
+	// configuration, closing the idle connections of the previous one.
+	Refresh(config *ClientConfiguration) error
+}
+
 // CreateFunc allows control over which implementation of a Client is
 // returned.  Users of the Client interface may need to create clients for
 // multiple brokers in a way that makes normal dependency injection
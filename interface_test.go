@@ -17,6 +17,8 @@ limitations under the License.
 package v2
 
 import (
+	"crypto/tls"
+	"net/http"
 	"testing"
 )
 
@@ -32,4 +34,86 @@ func TestDefaultClientConfiguration(t *testing.T) {
 	if testConfiguration.EnableAlphaFeatures != false {
 		t.Error("expected Alpha Features to be disabled")
 	}
+	if testConfiguration.MaxLogBodyBytes != 4096 {
+		t.Error("unexpected MaxLogBodyBytes")
+	}
+}
+
+// The concrete client satisfies each of the interfaces composing Client.
+var (
+	_ CatalogReader       = &client{}
+	_ Provisioner         = &client{}
+	_ Poller              = &client{}
+	_ Binder              = &client{}
+	_ StatusReader        = &client{}
+	_ ConfigurationReader = &client{}
+)
+
+func TestConfigurationReader(t *testing.T) {
+	config := DefaultClientConfiguration()
+	config.URL = "https://broker.example.com"
+	config.APIVersion = Version2_14()
+	config.EnableAlphaFeatures = true
+
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	if e, a := config.URL, c.BrokerURL(); e != a {
+		t.Errorf("unexpected broker URL; expected %v, got %v", e, a)
+	}
+	if e, a := config.APIVersion, c.BrokerAPIVersion(); e != a {
+		t.Errorf("unexpected API version; expected %v, got %v", e, a)
+	}
+	if e, a := config.EnableAlphaFeatures, c.AlphaEnabled(); e != a {
+		t.Errorf("unexpected alpha setting; expected %v, got %v", e, a)
+	}
+}
+
+func TestNewClientTLSRenegotiation(t *testing.T) {
+	cases := []struct {
+		name          string
+		tlsConfig     *tls.Config
+		renegotiation tls.RenegotiationSupport
+		expected      tls.RenegotiationSupport
+	}{
+		{
+			name:     "disabled by default",
+			expected: tls.RenegotiateNever,
+		},
+		{
+			name:          "enabled by configuration",
+			renegotiation: tls.RenegotiateOnceAsClient,
+			expected:      tls.RenegotiateOnceAsClient,
+		},
+		{
+			name:          "overrides TLSConfig",
+			tlsConfig:     &tls.Config{Renegotiation: tls.RenegotiateOnceAsClient},
+			renegotiation: tls.RenegotiateFreelyAsClient,
+			expected:      tls.RenegotiateFreelyAsClient,
+		},
+		{
+			name:      "TLSConfig kept when unset",
+			tlsConfig: &tls.Config{Renegotiation: tls.RenegotiateOnceAsClient},
+			expected:  tls.RenegotiateOnceAsClient,
+		},
+	}
+
+	for _, tc := range cases {
+		config := DefaultClientConfiguration()
+		config.URL = "https://broker.example.com"
+		config.TLSConfig = tc.tlsConfig
+		config.TLSRenegotiation = tc.renegotiation
+
+		c, err := NewClient(config)
+		if err != nil {
+			t.Fatalf("%v: unexpected error creating client: %v", tc.name, err)
+		}
+
+		transport := c.(*client).httpClient.Transport.(*http.Transport)
+		if e, a := tc.expected, transport.TLSClientConfig.Renegotiation; e != a {
+			t.Errorf("%v: unexpected renegotiation support; expected %v, got %v", tc.name, e, a)
+		}
+	}
 }
@@ -0,0 +1,46 @@
+package v2
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DecodeJSON base64-decodes the key and unmarshals the resulting JSON into
+// out, for the brokers that encode structured data, such as a task ID, in
+// their operation keys.  Both the standard and the URL-safe alphabets are
+// accepted, with or without padding.
+//
+// Operation keys are opaque in the OSB API: DecodeJSON is an interop
+// convenience for brokers known to use it this way, and returns an error
+// for any other key, which should be passed back to the broker unchanged.
+func (k OperationKey) DecodeJSON(out interface{}) error {
+	data, err := decodeBase64(strings.TrimSpace(string(k)))
+	if err != nil {
+		return fmt.Errorf("operation key is not base64-encoded: %v", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("operation key does not encode JSON: %v", err)
+	}
+	return nil
+}
+
+// decodeBase64 decodes s with the first base64 encoding it is valid in.
+func decodeBase64(s string) ([]byte, error) {
+	encodings := []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	}
+
+	var err error
+	for _, encoding := range encodings {
+		var data []byte
+		if data, err = encoding.DecodeString(s); err == nil {
+			return data, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,61 @@
+package v2
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func TestOperationKeyDecodeJSON(t *testing.T) {
+	type task struct {
+		TaskID string `json:"task_id"`
+		Step   int    `json:"step"`
+	}
+	structured := []byte(`{"task_id":"task-1?","step":2}`)
+
+	cases := []struct {
+		name               string
+		key                OperationKey
+		expected           *task
+		expectedErrMessage string
+	}{
+		{
+			name:     "standard encoding",
+			key:      OperationKey(base64.StdEncoding.EncodeToString(structured)),
+			expected: &task{TaskID: "task-1?", Step: 2},
+		},
+		{
+			name:     "URL-safe encoding without padding",
+			key:      OperationKey(base64.RawURLEncoding.EncodeToString(structured)),
+			expected: &task{TaskID: "task-1?", Step: 2},
+		},
+		{
+			name:               "opaque key",
+			key:                OperationKey("provision-in-progress"),
+			expectedErrMessage: "operation key is not base64-encoded: illegal base64 data at input byte 20",
+		},
+		{
+			name:               "base64 but not JSON",
+			key:                OperationKey(base64.StdEncoding.EncodeToString([]byte("task-1"))),
+			expectedErrMessage: "operation key does not encode JSON: invalid character 'a' in literal true (expecting 'r')",
+		},
+	}
+
+	for _, tc := range cases {
+		actual := &task{}
+		err := tc.key.DecodeJSON(actual)
+		if tc.expectedErrMessage != "" {
+			if err == nil || err.Error() != tc.expectedErrMessage {
+				t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expected, actual) {
+			t.Errorf("%v: expected %+v, got %+v", tc.name, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+)
+
+// originatingIdentityKey is the context key of the originating identity set
+// by WithOriginatingIdentity.
+type originatingIdentityKey struct{}
+
+// WithOriginatingIdentity returns a copy of ctx carrying the given
+// originating identity, so that middleware can set it once per request scope
+// instead of on every request struct.  The identity is used by the functions
+// taking a context, such as InstanceExists, BindAndWait, UnbindAll and
+// DeprovisionAll, for requests whose OriginatingIdentity field is nil.  An
+// OriginatingIdentity set explicitly on a request always takes precedence.
+func WithOriginatingIdentity(ctx context.Context, identity *OriginatingIdentity) context.Context {
+	return context.WithValue(ctx, originatingIdentityKey{}, identity)
+}
+
+// OriginatingIdentityFromContext returns the originating identity set on ctx
+// by WithOriginatingIdentity, or nil if there is none.
+func OriginatingIdentityFromContext(ctx context.Context) *OriginatingIdentity {
+	identity, _ := ctx.Value(originatingIdentityKey{}).(*OriginatingIdentity)
+	return identity
+}
+
+// withContextOriginatingIdentity returns explicit if it is set, and the originating
+// identity carried by ctx otherwise.
+func withContextOriginatingIdentity(ctx context.Context, explicit *OriginatingIdentity) *OriginatingIdentity {
+	if explicit != nil {
+		return explicit
+	}
+	return OriginatingIdentityFromContext(ctx)
+}
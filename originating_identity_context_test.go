@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestOriginatingIdentityFromContext(t *testing.T) {
+	explicit := &OriginatingIdentity{Platform: "explicit", Value: `{}`}
+	ctx := WithOriginatingIdentity(context.Background(), testOriginatingIdentity)
+
+	if e, a := (*OriginatingIdentity)(nil), OriginatingIdentityFromContext(context.Background()); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := testOriginatingIdentity, OriginatingIdentityFromContext(ctx); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := testOriginatingIdentity, withContextOriginatingIdentity(ctx, nil); e != a {
+		t.Errorf("expected context identity %v, got %v", e, a)
+	}
+	if e, a := explicit, withContextOriginatingIdentity(ctx, explicit); e != a {
+		t.Errorf("expected explicit identity to win, got %v", a)
+	}
+}
+
+func TestInstanceExistsOriginatingIdentityFromContext(t *testing.T) {
+	klient := newTestClient(t, "originating identity from context", LatestAPIVersion(), false, httpChecks{
+		headers: map[string]string{OriginatingIdentityHeader: testOriginatingIdentityHeaderValue},
+	}, httpReaction{
+		status: http.StatusOK,
+	})
+
+	ctx := WithOriginatingIdentity(context.Background(), testOriginatingIdentity)
+	if _, err := klient.InstanceExists(ctx, testInstanceID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// identityRecordingClient records the originating identity of bind requests.
+type identityRecordingClient struct {
+	bindAndWaitTestClient
+
+	bindIdentity *OriginatingIdentity
+}
+
+func (c *identityRecordingClient) Bind(r *BindRequest) (*BindResponse, error) {
+	c.bindIdentity = r.OriginatingIdentity
+	return c.bindAndWaitTestClient.Bind(r)
+}
+
+func TestBindAndWaitOriginatingIdentityFromContext(t *testing.T) {
+	client := &identityRecordingClient{
+		bindAndWaitTestClient: bindAndWaitTestClient{
+			bindResponse: func() *BindResponse {
+				r := testAsyncBindResponse()
+				r.Credentials = map[string]interface{}{"password": "secret"}
+				return r
+			}(),
+			states:  []LastOperationState{StateSucceeded},
+			binding: &GetBindingResponse{},
+		},
+	}
+	request := defaultAsyncBindRequest()
+	ctx := WithOriginatingIdentity(context.Background(), testOriginatingIdentity)
+
+	if _, err := BindAndWait(ctx, client, request, testPollOptions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := testOriginatingIdentity, client.bindIdentity; e != a {
+		t.Errorf("expected bind identity %v, got %v", e, a)
+	}
+	if e, a := testOriginatingIdentity, client.polls[0].OriginatingIdentity; e != a {
+		t.Errorf("expected poll identity %v, got %v", e, a)
+	}
+	if request.OriginatingIdentity != nil {
+		t.Errorf("expected the request to be left unmodified, got %v", request.OriginatingIdentity)
+	}
+}
+
+func TestDeprovisionAllOriginatingIdentityFromContext(t *testing.T) {
+	var identities []*OriginatingIdentity
+	client := &deprovisionIdentityClient{record: func(r *DeprovisionRequest) {
+		identities = append(identities, r.OriginatingIdentity)
+	}}
+	explicit := &OriginatingIdentity{Platform: "explicit", Value: `{}`}
+	withExplicit := defaultDeprovisionRequest()
+	withExplicit.OriginatingIdentity = explicit
+	ctx := WithOriginatingIdentity(context.Background(), testOriginatingIdentity)
+
+	DeprovisionAll(ctx, client, []*DeprovisionRequest{defaultDeprovisionRequest(), withExplicit}, 1)
+
+	if len(identities) != 2 || identities[0] != testOriginatingIdentity || identities[1] != explicit {
+		t.Errorf("expected the context identity then the explicit one, got %v", identities)
+	}
+}
+
+// deprovisionIdentityClient passes deprovision requests to record.
+type deprovisionIdentityClient struct {
+	Client
+
+	record func(*DeprovisionRequest)
+}
+
+func (c *deprovisionIdentityClient) DeprovisionInstance(r *DeprovisionRequest) (*DeprovisionResponse, error) {
+	c.record(r)
+	return &DeprovisionResponse{}, nil
+}
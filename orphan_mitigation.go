@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// RequiresOrphanMitigation returns whether an error returned by
+// ProvisionInstance or Bind calls for orphan mitigation, that is whether the
+// platform should deprovision the instance, or unbind the binding, because
+// the broker may have created it although the request did not succeed.
+// Following the orphan mitigation rules of the Open Service Broker API
+// specification, it returns true for:
+//
+//   - a 201 Created response whose body could not be read or unmarshaled
+//   - any other 2xx response than 200 OK and 201 Created, including a 202
+//     Accepted to a request that did not accept asynchronous operations
+//   - a 408 Request Timeout response
+//   - a 5xx response
+//   - a request that timed out before the broker responded, that is an error
+//     matching context.DeadlineExceeded or a net.Error reporting a timeout,
+//     such as the client's own TimeoutSeconds expiring
+//
+// It returns false for any other error, notably a 200 OK with a malformed
+// body, which means the resource already existed, any other 4xx response and
+// errors raised by the client before the request was sent.
+func RequiresOrphanMitigation(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if statusCodeError, ok := IsHTTPError(err); ok {
+		code := statusCodeError.StatusCode
+		switch {
+		case code == http.StatusOK:
+			return false
+		case code == http.StatusCreated:
+			return statusCodeError.ResponseError != nil
+		case code >= 200 && code <= 299:
+			return true
+		case code == http.StatusRequestTimeout:
+			return true
+		default:
+			return code >= http.StatusInternalServerError && code <= 599
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
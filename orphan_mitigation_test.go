@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type timeoutError struct{ timeout bool }
+
+func (e timeoutError) Error() string   { return "i/o error" }
+func (e timeoutError) Timeout() bool   { return e.timeout }
+func (e timeoutError) Temporary() bool { return false }
+
+func TestRequiresOrphanMitigation(t *testing.T) {
+	malformed := errors.New("unexpected end of JSON input")
+
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "no error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "200 with malformed body",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusOK, ResponseError: malformed},
+			expected: false,
+		},
+		{
+			name:     "201 with malformed body",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusCreated, ResponseError: malformed},
+			expected: true,
+		},
+		{
+			name:     "201 pointer with malformed body",
+			err:      &HTTPStatusCodeError{StatusCode: http.StatusCreated, ResponseError: malformed},
+			expected: true,
+		},
+		{
+			name:     "201 without response error",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusCreated},
+			expected: false,
+		},
+		{
+			name:     "unexpected 202",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusAccepted},
+			expected: true,
+		},
+		{
+			name:     "other 2xx",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusNoContent},
+			expected: true,
+		},
+		{
+			name:     "408",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusRequestTimeout},
+			expected: true,
+		},
+		{
+			name:     "other 4xx",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusConflict},
+			expected: false,
+		},
+		{
+			name:     "500",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusInternalServerError},
+			expected: true,
+		},
+		{
+			name:     "503",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusServiceUnavailable},
+			expected: true,
+		},
+		{
+			name:     "501",
+			err:      HTTPStatusCodeError{StatusCode: http.StatusNotImplemented},
+			expected: true,
+		},
+		{
+			name:     "context deadline exceeded",
+			err:      fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			expected: true,
+		},
+		{
+			name:     "client timeout",
+			err:      &url.Error{Op: "Put", URL: "https://example.com", Err: timeoutError{timeout: true}},
+			expected: true,
+		},
+		{
+			name:     "non-timeout network error",
+			err:      &url.Error{Op: "Put", URL: "https://example.com", Err: timeoutError{timeout: false}},
+			expected: false,
+		},
+		{
+			name:     "context canceled",
+			err:      context.Canceled,
+			expected: false,
+		},
+		{
+			name:     "validation error",
+			err:      required("instanceID"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, RequiresOrphanMitigation(tc.err); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
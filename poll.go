@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"time"
+)
+
+// defaultPollInterval is the delay between polls used when neither the
+// PollOptions nor the broker specify one.
+const defaultPollInterval = 10 * time.Second
+
+// PollOptions configures how the polling helpers wait for an asynchronous
+// operation to complete.
+type PollOptions struct {
+	// Interval is the delay between two polls of the last operation endpoint.
+	// A delay requested by the broker through the Retry-After header takes
+	// precedence.  Defaults to 10 seconds.
+	Interval time.Duration
+	// Deadline, if set, bounds the whole flow of a helper: the initial
+	// request plus every poll. It is not reset between polls. Once it
+	// passes, the helper returns a PollingTimeoutError carrying the last
+	// observed state of the operation. A deadline on the context passed to
+	// the helper is treated the same way.
+	Deadline time.Time
+	// MaxPolls, if positive, caps the number of polls of the last operation
+	// endpoint, whatever the delay between them. Once the cap is reached
+	// with the operation still in progress, the helper returns a
+	// PollingTimeoutError carrying the last response.
+	MaxPolls int
+	// Plan, if set, is the plan the operation is for.  If the plan declares
+	// a MaximumPollingDuration, the whole flow of a helper is bounded by it,
+	// unless Deadline or the deadline of the context is earlier.
+	Plan *Plan
+	// Metrics, if set, is told about each operation a helper waits for once
+	// the wait ends, whatever its outcome.
+	Metrics PollMetricsRecorder
+
+	// clock and newTimer, if set, replace time.Now and time.NewTimer so that
+	// tests can control the passage of time.
+	clock    func() time.Time
+	newTimer func(time.Duration) *time.Timer
+}
+
+// now returns the current time according to opts.
+func (opts PollOptions) now() time.Time {
+	if opts.clock != nil {
+		return opts.clock()
+	}
+	return time.Now()
+}
+
+// timer returns a timer firing after d according to opts.
+func (opts PollOptions) timer(d time.Duration) *time.Timer {
+	if opts.newTimer != nil {
+		return opts.newTimer(d)
+	}
+	return time.NewTimer(d)
+}
+
+// deadlinePassed returns whether the deadline of ctx, if any, has passed
+// according to opts.
+func (opts PollOptions) deadlinePassed(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && !opts.now().Before(deadline)
+}
+
+// PollMetricsRecorder records metrics about the asynchronous operations the
+// polling helpers wait for, for example to feed latency histograms.
+type PollMetricsRecorder interface {
+	// RecordOperation is called once per asynchronous operation waited for,
+	// with the kind of operation, such as "bind", the state it was last
+	// reported in, or an empty state if no poll succeeded, the number of
+	// polls of the last operation endpoint, failed ones included, and the
+	// time since the initial request was sent.
+	RecordOperation(operation string, state LastOperationState, polls int, duration time.Duration)
+}
+
+// recordOperation reports a finished wait to opts.Metrics, if set.
+func (opts PollOptions) recordOperation(operation string, state LastOperationState, polls int, started time.Time) {
+	if opts.Metrics == nil {
+		return
+	}
+	opts.Metrics.RecordOperation(operation, state, polls, opts.now().Sub(started))
+}
+
+// withDeadline returns a context bounded by opts.Deadline and the maximum
+// polling duration of opts.Plan, whichever is set and earliest.
+func (opts PollOptions) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline := opts.Deadline
+	if opts.Plan != nil && opts.Plan.MaximumPollingDuration != nil {
+		planDeadline := opts.now().Add(Seconds(*opts.Plan.MaximumPollingDuration).Duration())
+		if deadline.IsZero() || planDeadline.Before(deadline) {
+			deadline = planDeadline
+		}
+	}
+
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+// pollFunc performs a single poll of a last operation endpoint.
+type pollFunc func() (*LastOperationResponse, error)
+
+// pollUntilDone calls poll until the operation it reports is no longer in
+// progress, waiting between polls as configured by opts, and returns the
+// final response.  An error is returned if a poll fails, if the operation
+// fails, or if ctx is done before the operation completes.  If the deadline
+// of ctx passed, or if opts.MaxPolls is reached, the error is a
+// PollingTimeoutError.  The wait is then reported to opts.Metrics as an
+// operation of the given kind, started at the given time.
+func pollUntilDone(ctx context.Context, opts PollOptions, operation string, started time.Time, poll pollFunc) (final *LastOperationResponse, err error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	var last *LastOperationResponse
+	polls := 0
+	defer func() {
+		var state LastOperationState
+		if final != nil {
+			state = final.State
+		} else if last != nil {
+			state = last.State
+		}
+		opts.recordOperation(operation, state, polls, started)
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, pollingContextError(err, last)
+		}
+		if opts.deadlinePassed(ctx) {
+			return nil, PollingTimeoutError{LastResponse: last}
+		}
+
+		response, err := poll()
+		polls++
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.State {
+		case StateSucceeded:
+			return response, nil
+		case StateFailed:
+			return response, AsyncOperationFailedError{Description: response.Description}
+		}
+		last = response
+		if opts.MaxPolls > 0 && polls >= opts.MaxPolls {
+			return nil, PollingTimeoutError{LastResponse: last}
+		}
+
+		timer := opts.timer(response.nextPollDelay(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, pollingContextError(ctx.Err(), last)
+		case <-timer.C:
+		}
+	}
+}
+
+// waitForFirstPoll waits for the delay the broker requested in its initial
+// asynchronous response, if any, before the first poll of the operation,
+// timing it as configured by opts.
+func waitForFirstPoll(ctx context.Context, opts PollOptions, delay *time.Duration) error {
+	if delay == nil || *delay <= 0 {
+		return nil
+	}
+
+	timer := opts.timer(*delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return pollingContextError(ctx.Err(), nil)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pollingContextError converts the error of a done context into the error
+// returned by the polling helpers.
+func pollingContextError(err error, last *LastOperationResponse) error {
+	if err != context.DeadlineExceeded {
+		return err
+	}
+	return PollingTimeoutError{LastResponse: last}
+}
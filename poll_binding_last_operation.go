@@ -17,10 +17,9 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
-	"time"
 )
 
 func (c *client) PollBindingLastOperation(r *BindingLastOperationRequest) (*LastOperationResponse, error) {
@@ -34,6 +33,12 @@ func (c *client) PollBindingLastOperation(r *BindingLastOperationRequest) (*Last
 		return nil, err
 	}
 
+	if c.RequireLastOperationIDs {
+		if err := validateLastOperationIDs(r.ServiceID, r.PlanID); err != nil {
+			return nil, err
+		}
+	}
+
 	fullURL := fmt.Sprintf(bindingLastOperationURLFmt, c.URL, r.InstanceID, r.BindingID)
 	params := map[string]string{}
 
@@ -49,7 +54,7 @@ func (c *client) PollBindingLastOperation(r *BindingLastOperationRequest) (*Last
 		params[VarKeyOperation] = opStr
 	}
 
-	response, err := c.prepareAndDo(http.MethodGet, fullURL, params, nil /* request body */, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("PollBindingLastOperation", http.MethodGet, fullURL, params, nil /* request body */, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -67,12 +72,9 @@ func (c *client) PollBindingLastOperation(r *BindingLastOperationRequest) (*Last
 		}
 
 		if c.EnableAlphaFeatures {
-			delayInSeconds := response.Header.Get(PollingDelayHeader)
-			if delay, err := strconv.Atoi(delayInSeconds); err == nil {
-				pollDelay := time.Duration(delay) * time.Second
-				userResponse.PollDelay = &pollDelay
-			}
+			userResponse.PollDelay = c.pollDelay(response)
 		}
+		userResponse.Warnings = responseWarnings(response)
 
 		return userResponse, nil
 	default:
@@ -81,13 +83,15 @@ func (c *client) PollBindingLastOperation(r *BindingLastOperationRequest) (*Last
 }
 
 func validateBindingLastOperationRequest(request *BindingLastOperationRequest) error {
+	var errs []error
+
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.BindingID == "" {
-		return required("bindingID")
+		errs = append(errs, required("bindingID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
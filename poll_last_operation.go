@@ -17,10 +17,9 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
-	"time"
 )
 
 func (c *client) PollLastOperation(r *LastOperationRequest) (*LastOperationResponse, error) {
@@ -28,6 +27,12 @@ func (c *client) PollLastOperation(r *LastOperationRequest) (*LastOperationRespo
 		return nil, err
 	}
 
+	if c.RequireLastOperationIDs {
+		if err := validateLastOperationIDs(r.ServiceID, r.PlanID); err != nil {
+			return nil, err
+		}
+	}
+
 	fullURL := fmt.Sprintf(lastOperationURLFmt, c.URL, r.InstanceID)
 	params := map[string]string{}
 
@@ -43,7 +48,7 @@ func (c *client) PollLastOperation(r *LastOperationRequest) (*LastOperationRespo
 		params[VarKeyOperation] = opStr
 	}
 
-	response, err := c.prepareAndDo(http.MethodGet, fullURL, params, nil /* request body */, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("PollLastOperation", http.MethodGet, fullURL, params, nil /* request body */, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +66,9 @@ func (c *client) PollLastOperation(r *LastOperationRequest) (*LastOperationRespo
 		}
 
 		if c.EnableAlphaFeatures {
-			if delay, err := strconv.Atoi(response.Header.Get(PollingDelayHeader)); err == nil && delay > 0 {
-				duration := time.Duration(delay) * time.Second
-				userResponse.PollDelay = &duration
-			}
+			userResponse.PollDelay = c.pollDelay(response)
 		}
+		userResponse.Warnings = responseWarnings(response)
 
 		return userResponse, nil
 	default:
@@ -74,9 +77,27 @@ func (c *client) PollLastOperation(r *LastOperationRequest) (*LastOperationRespo
 }
 
 func validateLastOperationRequest(request *LastOperationRequest) error {
+	var errs []error
+
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateLastOperationIDs returns an error if either of the given service
+// and plan IDs is unset.
+func validateLastOperationIDs(serviceID, planID *string) error {
+	var errs []error
+
+	if serviceID == nil || *serviceID == "" {
+		errs = append(errs, required("serviceID"))
+	}
+
+	if planID == nil || *planID == "" {
+		errs = append(errs, required("planID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
@@ -0,0 +1,19 @@
+package v2
+
+import "time"
+
+// NextPollTime returns the time at which the operation should be polled
+// again: now plus the PollDelay requested by the broker if set, else now
+// plus fallback.
+func (r *LastOperationResponse) NextPollTime(now time.Time, fallback time.Duration) time.Time {
+	return now.Add(r.nextPollDelay(fallback))
+}
+
+// nextPollDelay returns the delay before the operation should be polled
+// again, giving precedence to the PollDelay requested by the broker.
+func (r *LastOperationResponse) nextPollDelay(fallback time.Duration) time.Duration {
+	if r.PollDelay != nil {
+		return *r.PollDelay
+	}
+	return fallback
+}
@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextPollTime(t *testing.T) {
+	now := time.Date(2021, time.January, 23, 23, 12, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		response *LastOperationResponse
+		fallback time.Duration
+		expected time.Time
+	}{
+		{
+			name:     "no poll delay",
+			response: &LastOperationResponse{State: StateInProgress},
+			fallback: 10 * time.Second,
+			expected: now.Add(10 * time.Second),
+		},
+		{
+			name: "poll delay takes precedence",
+			response: &LastOperationResponse{
+				State:     StateInProgress,
+				PollDelay: durationPtr(30 * time.Second),
+			},
+			fallback: 10 * time.Second,
+			expected: now.Add(30 * time.Second),
+		},
+		{
+			name: "zero poll delay",
+			response: &LastOperationResponse{
+				State:     StateInProgress,
+				PollDelay: durationPtr(0),
+			},
+			fallback: 10 * time.Second,
+			expected: now,
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, tc.response.NextPollTime(now, tc.fallback); !e.Equal(a) {
+			t.Errorf("%v: unexpected next poll time; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
@@ -280,3 +280,52 @@ func TestValidateLastOperationRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestPollLastOperationRequireIDs(t *testing.T) {
+	cases := []struct {
+		name               string
+		request            *LastOperationRequest
+		expectedErrMessage string
+	}{
+		{
+			name:    "IDs set",
+			request: defaultLastOperationRequest(),
+		},
+		{
+			name: "missing service ID",
+			request: func() *LastOperationRequest {
+				r := defaultLastOperationRequest()
+				r.ServiceID = nil
+				return r
+			}(),
+			expectedErrMessage: "serviceID is required",
+		},
+		{
+			name: "empty plan ID",
+			request: func() *LastOperationRequest {
+				r := defaultLastOperationRequest()
+				r.PlanID = strPtr("")
+				return r
+			}(),
+			expectedErrMessage: "planID is required",
+		},
+	}
+
+	for _, tc := range cases {
+		httpReaction := httpReaction{
+			status: http.StatusOK,
+			body:   successLastOperationResponseBody,
+		}
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction)
+		klient.RequireLastOperationIDs = true
+
+		var expectedResponse *LastOperationResponse
+		if tc.expectedErrMessage == "" {
+			expectedResponse = successLastOperationResponse()
+		}
+
+		response, err := klient.PollLastOperation(tc.request)
+
+		doResponseChecks(t, tc.name, response, err, expectedResponse, tc.expectedErrMessage, nil)
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPollUntilDoneCancelledDuringDelay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	poll := func() (*LastOperationResponse, error) {
+		polls++
+		return &LastOperationResponse{
+			State:     StateInProgress,
+			PollDelay: durationPtr(time.Hour),
+		}, nil
+	}
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+	response, err := pollUntilDone(ctx, PollOptions{Interval: time.Hour}, "test", time.Now(), poll)
+	elapsed := time.Since(start)
+
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if response != nil {
+		t.Errorf("expected no response, got %+v", response)
+	}
+	if elapsed > time.Second {
+		t.Errorf("expected cancellation to interrupt the poll delay, returned after %v", elapsed)
+	}
+	if e, a := 1, polls; e != a {
+		t.Errorf("expected %v polls, got %v", e, a)
+	}
+}
+
+func TestPollUntilDoneAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	poll := func() (*LastOperationResponse, error) {
+		t.Error("unexpected poll with a cancelled context")
+		return nil, nil
+	}
+
+	if _, err := pollUntilDone(ctx, PollOptions{}, "test", time.Now(), poll); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestPollUntilDoneDeadlineBeforeFirstPoll(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	poll := func() (*LastOperationResponse, error) {
+		t.Error("unexpected poll after the deadline")
+		return nil, nil
+	}
+
+	_, err := pollUntilDone(ctx, PollOptions{}, "test", time.Now(), poll)
+	if e, a := (PollingTimeoutError{}), err; e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
+func TestPollUntilDoneMaxPolls(t *testing.T) {
+	polls := 0
+	poll := func() (*LastOperationResponse, error) {
+		polls++
+		return &LastOperationResponse{
+			State:       StateInProgress,
+			Description: strPtr("still going"),
+			PollDelay:   durationPtr(0),
+		}, nil
+	}
+
+	response, err := pollUntilDone(context.Background(), PollOptions{MaxPolls: 3}, "test", time.Now(), poll)
+
+	if response != nil {
+		t.Errorf("expected no response, got %+v", response)
+	}
+	if e, a := 3, polls; e != a {
+		t.Errorf("expected %v polls, got %v", e, a)
+	}
+	timeoutErr, ok := err.(PollingTimeoutError)
+	if !ok {
+		t.Fatalf("expected a PollingTimeoutError, got %v", err)
+	}
+	if timeoutErr.LastResponse == nil || timeoutErr.LastResponse.Description == nil || *timeoutErr.LastResponse.Description != "still going" {
+		t.Errorf("expected the last response to be carried, got %+v", timeoutErr.LastResponse)
+	}
+}
+
+func TestPollUntilDoneMaxPollsSucceedsOnLastPoll(t *testing.T) {
+	states := []LastOperationState{StateInProgress, StateSucceeded}
+	poll := func() (*LastOperationResponse, error) {
+		state := states[0]
+		states = states[1:]
+		return &LastOperationResponse{State: state, PollDelay: durationPtr(0)}, nil
+	}
+
+	response, err := pollUntilDone(context.Background(), PollOptions{MaxPolls: 2}, "test", time.Now(), poll)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := StateSucceeded, response.State; e != a {
+		t.Errorf("expected state %v, got %v", e, a)
+	}
+}
+
+// fakePollClock is a clock for PollOptions whose timers fire at once,
+// moving the clock forward by their duration.
+type fakePollClock struct {
+	now time.Time
+}
+
+func (c *fakePollClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakePollClock) NewTimer(d time.Duration) *time.Timer {
+	c.now = c.now.Add(d)
+	return time.NewTimer(0)
+}
+
+func TestPollOptionsWithDeadline(t *testing.T) {
+	int64Ptr := func(i int64) *int64 { return &i }
+	now := time.Now()
+
+	cases := []struct {
+		name             string
+		opts             PollOptions
+		expectedDeadline time.Time
+	}{
+		{
+			name: "no deadline",
+		},
+		{
+			name:             "explicit deadline",
+			opts:             PollOptions{Deadline: now.Add(time.Hour)},
+			expectedDeadline: now.Add(time.Hour),
+		},
+		{
+			name:             "plan maximum polling duration",
+			opts:             PollOptions{Plan: &Plan{MaximumPollingDuration: int64Ptr(60)}},
+			expectedDeadline: now.Add(time.Minute),
+		},
+		{
+			name: "plan without maximum polling duration",
+			opts: PollOptions{Plan: &Plan{}},
+		},
+		{
+			name: "shorter explicit deadline wins",
+			opts: PollOptions{
+				Deadline: now.Add(time.Second),
+				Plan:     &Plan{MaximumPollingDuration: int64Ptr(60)},
+			},
+			expectedDeadline: now.Add(time.Second),
+		},
+		{
+			name: "shorter plan maximum polling duration wins",
+			opts: PollOptions{
+				Deadline: now.Add(time.Hour),
+				Plan:     &Plan{MaximumPollingDuration: int64Ptr(60)},
+			},
+			expectedDeadline: now.Add(time.Minute),
+		},
+	}
+
+	for _, tc := range cases {
+		tc.opts.clock = fakeClock(now)
+		ctx, cancel := tc.opts.withDeadline(context.Background())
+		deadline, ok := ctx.Deadline()
+		cancel()
+
+		if e, a := !tc.expectedDeadline.IsZero(), ok; e != a {
+			t.Errorf("%v: expected deadline set to be %v, got %v", tc.name, e, a)
+			continue
+		}
+		if e, a := tc.expectedDeadline, deadline; !e.Equal(a) {
+			t.Errorf("%v: unexpected deadline; expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+// recordedOperation is an operation reported to a testPollMetricsRecorder.
+type recordedOperation struct {
+	operation string
+	state     LastOperationState
+	polls     int
+}
+
+type testPollMetricsRecorder struct {
+	operations []recordedOperation
+}
+
+func (r *testPollMetricsRecorder) RecordOperation(operation string, state LastOperationState, polls int, duration time.Duration) {
+	if duration < 0 {
+		panic("negative duration")
+	}
+	r.operations = append(r.operations, recordedOperation{operation: operation, state: state, polls: polls})
+}
+
+func TestPollUntilDoneRecordsMetrics(t *testing.T) {
+	cases := []struct {
+		name     string
+		states   []LastOperationState
+		maxPolls int
+		expected recordedOperation
+	}{
+		{
+			name:     "succeeded",
+			states:   []LastOperationState{StateInProgress, StateSucceeded},
+			expected: recordedOperation{operation: "test", state: StateSucceeded, polls: 2},
+		},
+		{
+			name:     "failed",
+			states:   []LastOperationState{StateFailed},
+			expected: recordedOperation{operation: "test", state: StateFailed, polls: 1},
+		},
+		{
+			name:     "max polls",
+			states:   []LastOperationState{StateInProgress, StateInProgress, StateInProgress},
+			maxPolls: 2,
+			expected: recordedOperation{operation: "test", state: StateInProgress, polls: 2},
+		},
+	}
+
+	for _, tc := range cases {
+		recorder := &testPollMetricsRecorder{}
+		states := tc.states
+		poll := func() (*LastOperationResponse, error) {
+			state := states[0]
+			states = states[1:]
+			return &LastOperationResponse{State: state, PollDelay: durationPtr(0)}, nil
+		}
+
+		pollUntilDone(context.Background(), PollOptions{MaxPolls: tc.maxPolls, Metrics: recorder}, "test", time.Now(), poll)
+
+		if e, a := []recordedOperation{tc.expected}, recorder.operations; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
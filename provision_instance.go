@@ -17,9 +17,11 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/google/uuid"
 	"k8s.io/klog/v2"
 )
 
@@ -30,17 +32,28 @@ type provisionRequestBody struct {
 	PlanID           string                 `json:"plan_id"`
 	OrganizationGUID string                 `json:"organization_guid"`
 	SpaceGUID        string                 `json:"space_guid"`
-	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	Parameters       interface{}            `json:"parameters,omitempty"`
 	Context          map[string]interface{} `json:"context,omitempty"`
 }
 
 type provisionSuccessResponseBody struct {
-	DashboardURL *string                  `json:"dashboard_url"`
+	DashboardURL dashboardField           `json:"dashboard_url"`
 	Metadata     *ServiceInstanceMetadata `json:"metadata,omitempty"`
-	Operation    *string                  `json:"operation"`
+	asyncOperationFields
 }
 
 func (c *client) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, error) {
+	if r.InstanceID == "" && c.GenerateInstanceIDs {
+		// Work on a copy: the caller's request is never modified, and the
+		// generated ID is returned on the response instead.
+		generated := *r
+		generated.InstanceID = uuid.New().String()
+		r = &generated
+		if c.Verbose {
+			klog.Infof("broker %q: generated instance ID %q", c.Name, r.InstanceID)
+		}
+	}
+
 	if err := validateProvisionRequest(r); err != nil {
 		return nil, err
 	}
@@ -48,23 +61,22 @@ func (c *client) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, err
 	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.URL, r.InstanceID)
 
 	params := map[string]string{}
-	if r.AcceptsIncomplete {
-		params[AcceptsIncomplete] = "true"
-	}
+	acceptsIncomplete := c.acceptsIncomplete(r.AcceptsIncomplete)
+	setAcceptsIncomplete(params, acceptsIncomplete, r.SendAcceptsIncompleteFalse)
 
 	requestBody := &provisionRequestBody{
 		ServiceID:        r.ServiceID,
 		PlanID:           r.PlanID,
 		OrganizationGUID: r.OrganizationGUID,
 		SpaceGUID:        r.SpaceGUID,
-		Parameters:       r.Parameters,
+		Parameters:       requestParameters(r.Parameters, r.SendEmptyParameters),
 	}
 
 	if c.APIVersion.AtLeast(Version2_12()) {
-		requestBody.Context = r.Context
+		requestBody.Context = c.requestContext(r.Context)
 	}
 
-	response, err := c.prepareAndDo(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("ProvisionInstance", http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -76,14 +88,28 @@ func (c *client) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, err
 
 	switch response.StatusCode {
 	case http.StatusCreated, http.StatusOK:
-		userResponse := &ProvisionResponse{}
-		if err := c.unmarshalResponse(response, userResponse); err != nil {
+		responseBodyObj := &provisionSuccessResponseBody{}
+		if err := c.unmarshalResponse(response, responseBodyObj); err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+
+		opPtr, _, err := decodeOperationKey(responseBodyObj.Operation)
+		if err != nil {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
+		userResponse := &ProvisionResponse{
+			InstanceID:   r.InstanceID,
+			DashboardURL: responseBodyObj.DashboardURL.URL,
+			Dashboards:   responseBodyObj.DashboardURL.Dashboards,
+			Metadata:     responseBodyObj.Metadata,
+			OperationKey: opPtr,
+			Warnings:     responseWarnings(response),
+		}
+
 		return userResponse, nil
 	case http.StatusAccepted:
-		if !r.AcceptsIncomplete {
+		if !acceptsIncomplete {
 			// If the client did not signify that it could handle asynchronous
 			// operations, a '202 Accepted' response should be treated as an error.
 			return nil, c.handleFailureResponse(response)
@@ -94,18 +120,22 @@ func (c *client) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, err
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
-		var opPtr *OperationKey
-		if responseBodyObj.Operation != nil {
-			opStr := *responseBodyObj.Operation
-			op := OperationKey(opStr)
-			opPtr = &op
+		opPtr, err := c.operationKey(responseBodyObj.asyncOperationFields)
+		if err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
 		userResponse := &ProvisionResponse{
+			InstanceID:   r.InstanceID,
 			Async:        true,
-			DashboardURL: responseBodyObj.DashboardURL,
+			DashboardURL: responseBodyObj.DashboardURL.URL,
+			Dashboards:   responseBodyObj.DashboardURL.Dashboards,
 			Metadata:     responseBodyObj.Metadata,
 			OperationKey: opPtr,
+			Warnings:     responseWarnings(response),
+		}
+		if c.EnableAlphaFeatures {
+			userResponse.PollDelay = c.pollDelay(response)
 		}
 
 		if c.Verbose {
@@ -118,30 +148,45 @@ func (c *client) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, err
 	}
 }
 
+// requestParameters returns the value to marshal as the parameters of a
+// request body: nil, so that the field is omitted, if parameters is empty
+// and sendEmpty is unset, or else a map that is never nil.
+func requestParameters(parameters map[string]interface{}, sendEmpty bool) interface{} {
+	if len(parameters) > 0 {
+		return parameters
+	}
+	if sendEmpty {
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
 func required(name string) error {
 	return fmt.Errorf("%v is required", name)
 }
 
 func validateProvisionRequest(request *ProvisionRequest) error {
+	var errs []error
+
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.ServiceID == "" {
-		return required("serviceID")
+		errs = append(errs, required("serviceID"))
 	}
 
 	if request.PlanID == "" {
-		return required("planID")
+		errs = append(errs, required("planID"))
 	}
 
 	if request.OrganizationGUID == "" {
-		return required("organizationGUID")
+		errs = append(errs, required("organizationGUID"))
 	}
 
 	if request.SpaceGUID == "" {
-		return required("spaceGUID")
+		errs = append(errs, required("spaceGUID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
@@ -1,12 +1,22 @@
 package v2
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
 // IsAsync returns true if the provision request is being handled asynchronously.
 func (r *ProvisionResponse) IsAsync() bool {
 	return r.Async
 }
 
-// GetOperationKey returns the operation key for the asynchronous provision request.
+// GetOperationKey returns the operation key for the asynchronous provision
+// request, or nil if r is nil.
 func (r *ProvisionResponse) GetOperationKey() *OperationKey {
+	if r == nil {
+		return nil
+	}
 	return r.OperationKey
 }
 
@@ -14,3 +24,35 @@ func (r *ProvisionResponse) GetOperationKey() *OperationKey {
 func (r *ProvisionResponse) GetDashboardURL() *string {
 	return r.DashboardURL
 }
+
+// SetParameters sets the Parameters of the request from v, which may be any
+// value that encoding/json marshals into an object, such as a struct with
+// json tags.  Any Parameters previously set are replaced, so the map should
+// be edited after calling SetParameters, not before.  Numbers are kept as
+// json.Number so that they are sent to the broker unchanged.
+func (r *ProvisionRequest) SetParameters(v interface{}) error {
+	parameters, err := toParameters(v)
+	if err != nil {
+		return err
+	}
+	r.Parameters = parameters
+	return nil
+}
+
+// toParameters converts v into a parameters map by round-tripping it through
+// JSON.
+func toParameters(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var parameters map[string]interface{}
+	if err := decoder.Decode(&parameters); err != nil {
+		return nil, fmt.Errorf("parameters must marshal into a JSON object: %v", err)
+	}
+	return parameters, nil
+}
@@ -0,0 +1,67 @@
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestProvisionRequestSetParameters(t *testing.T) {
+	type storage struct {
+		SizeGB int64  `json:"size_gb"`
+		Class  string `json:"class,omitempty"`
+	}
+	type parameters struct {
+		Name     string            `json:"name"`
+		Replicas int               `json:"replicas"`
+		Storage  storage           `json:"storage"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Internal string            `json:"-"`
+	}
+
+	typed := parameters{
+		Name:     "db",
+		Replicas: 3,
+		Storage:  storage{SizeGB: 9007199254740993},
+		Labels:   map[string]string{"team": "data"},
+		Internal: "not sent",
+	}
+
+	r := defaultProvisionRequest()
+	r.Parameters = map[string]interface{}{"stale": true}
+	if err := r.SetParameters(typed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := r.Parameters["stale"]; ok {
+		t.Errorf("expected previous parameters to be replaced, got %v", r.Parameters)
+	}
+
+	data, err := json.Marshal(r.Parameters)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling parameters: %v", err)
+	}
+
+	var roundTripped parameters
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("unexpected error unmarshalling parameters: %v", err)
+	}
+	typed.Internal = ""
+	if e, a := typed, roundTripped; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected parameters; expected %+v, got %+v", e, a)
+	}
+
+	if err := r.SetParameters(nil); err != nil {
+		t.Fatalf("unexpected error clearing parameters: %v", err)
+	}
+	if r.Parameters != nil {
+		t.Errorf("expected nil parameters, got %v", r.Parameters)
+	}
+
+	if err := r.SetParameters([]string{"not", "an", "object"}); err == nil {
+		t.Error("expected error setting parameters from a slice")
+	}
+	if err := r.SetParameters(func() {}); err == nil {
+		t.Error("expected error setting parameters from a value that cannot be marshalled")
+	}
+}
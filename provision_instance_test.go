@@ -18,8 +18,12 @@ package v2
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -81,12 +85,14 @@ var metadata = ServiceInstanceMetadata{
 
 func successProvisionResponse() *ProvisionResponse {
 	return &ProvisionResponse{
+		InstanceID:   testInstanceID,
 		DashboardURL: &testDashboardURL,
 	}
 }
 
 func successProvisionResponseWithMetadata() *ProvisionResponse {
 	return &ProvisionResponse{
+		InstanceID:   testInstanceID,
 		DashboardURL: &testDashboardURL,
 		Metadata:     &metadata,
 	}
@@ -144,6 +150,111 @@ func TestProvisionInstance(t *testing.T) {
 			},
 			expectedResponse: successProvisionResponse(),
 		},
+		{
+			name: "nil parameters are omitted",
+			request: func() *ProvisionRequest {
+				r := defaultProvisionRequest()
+				r.Parameters = nil
+				return r
+			}(),
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			expectedResponse: successProvisionResponse(),
+		},
+		{
+			name: "empty parameters are omitted",
+			request: func() *ProvisionRequest {
+				r := defaultProvisionRequest()
+				r.Parameters = map[string]interface{}{}
+				return r
+			}(),
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			expectedResponse: successProvisionResponse(),
+		},
+		{
+			name: "nil parameters sent as empty object",
+			request: func() *ProvisionRequest {
+				r := defaultProvisionRequest()
+				r.SendEmptyParameters = true
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: `{"service_id":"test-service-id","plan_id":"test-plan-id","organization_guid":"test-organization-guid","space_guid":"test-space-guid","parameters":{}}`,
+			},
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			expectedResponse: successProvisionResponse(),
+		},
+		{
+			name: "empty parameters sent as empty object",
+			request: func() *ProvisionRequest {
+				r := defaultProvisionRequest()
+				r.Parameters = map[string]interface{}{}
+				r.SendEmptyParameters = true
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: `{"service_id":"test-service-id","plan_id":"test-plan-id","organization_guid":"test-organization-guid","space_guid":"test-space-guid","parameters":{}}`,
+			},
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			expectedResponse: successProvisionResponse(),
+		},
+		{
+			name: "non-empty parameters sent regardless of SendEmptyParameters",
+			request: func() *ProvisionRequest {
+				r := defaultProvisionRequest()
+				r.Parameters = map[string]interface{}{"size": "small"}
+				r.SendEmptyParameters = true
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: `{"service_id":"test-service-id","plan_id":"test-plan-id","organization_guid":"test-organization-guid","space_guid":"test-space-guid","parameters":{"size":"small"}}`,
+			},
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			expectedResponse: successProvisionResponse(),
+		},
+		{
+			name: "success - ok with dashboard object",
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   `{"dashboard_url": {"url": "https://example.com/dashboard", "label": "main"}}`,
+			},
+			expectedResponse: &ProvisionResponse{
+				InstanceID:   testInstanceID,
+				DashboardURL: &testDashboardURL,
+				Dashboards: []Dashboard{
+					{URL: testDashboardURL, Label: "main"},
+				},
+			},
+		},
+		{
+			name: "success - created with dashboard list",
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   `{"dashboard_url": [{"url": "https://example.com/dashboard", "label": "main"}, "https://example.com/admin"]}`,
+			},
+			expectedResponse: &ProvisionResponse{
+				InstanceID:   testInstanceID,
+				DashboardURL: &testDashboardURL,
+				Dashboards: []Dashboard{
+					{URL: testDashboardURL, Label: "main"},
+					{URL: "https://example.com/admin"},
+				},
+			},
+		},
 		{
 			name: "success - ok with metadata",
 			httpReaction: httpReaction{
@@ -152,6 +263,47 @@ func TestProvisionInstance(t *testing.T) {
 			},
 			expectedResponse: successProvisionResponseWithMetadata(),
 		},
+		{
+			name: "success - created with warning headers",
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+				header: map[string][]string{WarningHeader: {
+					`299 - "plan small is deprecated"`,
+					`299 - "running in degraded mode"`,
+				}},
+			},
+			expectedResponse: func() *ProvisionResponse {
+				r := successProvisionResponse()
+				r.Warnings = []string{
+					`299 - "plan small is deprecated"`,
+					`299 - "running in degraded mode"`,
+				}
+				return r
+			}(),
+		},
+		{
+			name:        "success - asynchronous with retry delay header",
+			version:     LatestAPIVersion(),
+			enableAlpha: true,
+			request:     defaultAsyncProvisionRequest(),
+			httpChecks: httpChecks{
+				params: map[string]string{
+					AcceptsIncomplete: "true",
+				},
+				body: successProvisionRequestBody,
+			},
+			httpReaction: httpReaction{
+				status: http.StatusAccepted,
+				body:   successAsyncProvisionResponseBody,
+				header: map[string][]string{PollingDelayHeader: {"30"}},
+			},
+			expectedResponse: func() *ProvisionResponse {
+				r := successProvisionResponseAsync()
+				r.PollDelay = durationPtr(30 * time.Second)
+				return r
+			}(),
+		},
 		{
 			name:    "success - asynchronous",
 			request: defaultAsyncProvisionRequest(),
@@ -166,6 +318,24 @@ func TestProvisionInstance(t *testing.T) {
 			},
 			expectedResponse: successProvisionResponseAsync(),
 		},
+		{
+			name: "success - explicit accepts_incomplete=false",
+			request: func() *ProvisionRequest {
+				r := defaultProvisionRequest()
+				r.SendAcceptsIncompleteFalse = true
+				return r
+			}(),
+			httpChecks: httpChecks{
+				params: map[string]string{
+					AcceptsIncomplete: "false",
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusCreated,
+				body:   successProvisionResponseBody,
+			},
+			expectedResponse: successProvisionResponse(),
+		},
 		{
 			name: "http error",
 			httpReaction: httpReaction{
@@ -205,6 +375,20 @@ func TestProvisionInstance(t *testing.T) {
 			},
 			expectedErr: testHTTPStatusCodeError(),
 		},
+		{
+			name: "429 with retry delay header",
+			httpReaction: httpReaction{
+				status: http.StatusTooManyRequests,
+				body:   conventionalFailureResponseBody,
+				header: map[string][]string{PollingDelayHeader: {"10"}},
+			},
+			expectedErr: func() error {
+				err := testHTTPStatusCodeError().(HTTPStatusCodeError)
+				err.StatusCode = http.StatusTooManyRequests
+				err.RetryAfter = durationPtr(10 * time.Second)
+				return err
+			}(),
+		},
 		{
 			name:    "context - 2.12",
 			version: Version2_12(),
@@ -373,3 +557,138 @@ func TestValidateProvisionRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestProvisionInstanceGeneratedInstanceID(t *testing.T) {
+	cases := []struct {
+		name               string
+		generate           bool
+		instanceID         string
+		expectedErrMessage string
+	}{
+		{
+			name:     "generated when empty",
+			generate: true,
+		},
+		{
+			name:       "kept when set",
+			generate:   true,
+			instanceID: testInstanceID,
+		},
+		{
+			name:               "required without generation",
+			expectedErrMessage: "instanceID is required",
+		},
+	}
+
+	for _, tc := range cases {
+		request := defaultProvisionRequest()
+		request.InstanceID = tc.instanceID
+
+		var requestedPath string
+		klient := newTestClient(t, tc.name, Version2_11(), false, httpChecks{}, httpReaction{})
+		klient.GenerateInstanceIDs = tc.generate
+		klient.doRequestFunc = func(r *http.Request) (*http.Response, error) {
+			requestedPath = r.URL.Path
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       closer(successProvisionResponseBody),
+			}, nil
+		}
+
+		response, err := klient.ProvisionInstance(request)
+		if tc.expectedErrMessage != "" {
+			if err == nil || err.Error() != tc.expectedErrMessage {
+				t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		if e, a := tc.instanceID, request.InstanceID; e != a {
+			t.Errorf("%v: expected the request's instance ID %q to be left unchanged, got %q", tc.name, e, a)
+		}
+		if tc.instanceID != "" && response.InstanceID != tc.instanceID {
+			t.Errorf("%v: expected instance ID %v to be kept, got %v", tc.name, tc.instanceID, response.InstanceID)
+		}
+		if err := uuid.Validate(response.InstanceID); tc.instanceID == "" && err != nil {
+			t.Errorf("%v: expected generated instance ID to be a GUID: %v", tc.name, err)
+		}
+		if e, a := "/v2/service_instances/"+response.InstanceID, requestedPath; e != a {
+			t.Errorf("%v: expected request to %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestProvisionInstanceOperationKeyAlias(t *testing.T) {
+	klient := newTestClient(t, "operation key alias", Version2_11(), false, httpChecks{body: successProvisionRequestBody}, httpReaction{
+		status: http.StatusAccepted,
+		body:   `{"dashboard_url": "https://example.com/dashboard", "operation_key": "test-operation-key"}`,
+	})
+	klient.AcceptOperationKeyAliases = true
+
+	response, err := klient.ProvisionInstance(defaultAsyncProvisionRequest())
+
+	doResponseChecks(t, "operation key alias", response, err, successProvisionResponseAsync(), "", nil)
+}
+
+func TestValidateProvisionRequestReportsAllErrors(t *testing.T) {
+	err := validateProvisionRequest(&ProvisionRequest{
+		InstanceID: testInstanceID,
+		ServiceID:  testServiceID,
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := "planID is required\norganizationGUID is required\nspaceGUID is required"
+	if e, a := expected, err.Error(); e != a {
+		t.Errorf("unexpected error; expected %q, got %q", e, a)
+	}
+}
+
+func TestProvisionInstanceBodyIsDeterministic(t *testing.T) {
+	request := defaultProvisionRequest()
+	request.Parameters = map[string]interface{}{
+		"zeta":  "z",
+		"alpha": "a",
+		"mu":    map[string]interface{}{"y": 1, "b": 2, "k": 3},
+		"beta":  []interface{}{map[string]interface{}{"d": 4, "c": 5}},
+	}
+	request.Context = map[string]interface{}{
+		"space_guid":        "test-space-guid",
+		"organization_guid": "test-organization-guid",
+		"platform":          "cloudfoundry",
+	}
+
+	var bodies []string
+	klient := newTestClient(t, "deterministic body", Version2_13(), false, httpChecks{}, httpReaction{})
+	klient.doRequestFunc = func(request *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, string(body))
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       closer(successProvisionResponseBody),
+		}, nil
+	}
+
+	for i := 0; i < 20; i++ {
+		if _, err := klient.ProvisionInstance(request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := `{"service_id":"test-service-id","plan_id":"test-plan-id","organization_guid":"test-organization-guid","space_guid":"test-space-guid",` +
+		`"parameters":{"alpha":"a","beta":[{"c":5,"d":4}],"mu":{"b":2,"k":3,"y":1},"zeta":"z"},` +
+		`"context":{"organization_guid":"test-organization-guid","platform":"cloudfoundry","space_guid":"test-space-guid"}}`
+	for i, body := range bodies {
+		if e, a := expected, body; e != a {
+			t.Errorf("body %d: expected %v, got %v", i, e, a)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPtr(t *testing.T) {
+	b := Ptr(true)
+	if b == nil || !*b {
+		t.Errorf("expected pointer to true, got %v", b)
+	}
+
+	s := "value"
+	p := Ptr(s)
+	s = "changed"
+	if e, a := "value", *p; e != a {
+		t.Errorf("expected pointer to a copy holding %q, got %q", e, a)
+	}
+
+	if e, a := int64(42), *Ptr[int64](42); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	if e, a := "value", Deref(strPtr("value"), "default"); e != a {
+		t.Errorf("expected %q, got %q", e, a)
+	}
+	if e, a := "default", Deref(nil, "default"); e != a {
+		t.Errorf("expected %q, got %q", e, a)
+	}
+	if e, a := false, Deref(falsePtr(), true); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := true, Deref((*bool)(nil), true); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	if e, a := time.Second, Deref((*time.Duration)(nil), time.Second); e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+}
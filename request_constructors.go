@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+// NewProvisionRequest returns a request to provision the given instance of
+// the given service and plan in the given organization and space, the fields
+// required to provision an instance.  Other fields are left to their zero
+// value, so the request is synchronous unless AcceptsIncomplete is set.
+func NewProvisionRequest(instanceID, serviceID, planID, organizationGUID, spaceGUID string) *ProvisionRequest {
+	return &ProvisionRequest{
+		InstanceID:       instanceID,
+		ServiceID:        serviceID,
+		PlanID:           planID,
+		OrganizationGUID: organizationGUID,
+		SpaceGUID:        spaceGUID,
+	}
+}
+
+// NewUpdateInstanceRequest returns a request to update the given instance of
+// the given service.  The plan and parameters of the instance are left
+// unchanged unless PlanID or Parameters are set.
+func NewUpdateInstanceRequest(instanceID, serviceID string) *UpdateInstanceRequest {
+	return &UpdateInstanceRequest{
+		InstanceID: instanceID,
+		ServiceID:  serviceID,
+	}
+}
+
+// NewDeprovisionRequest returns a request to deprovision the given instance
+// of the given service and plan.
+func NewDeprovisionRequest(instanceID, serviceID, planID string) *DeprovisionRequest {
+	return &DeprovisionRequest{
+		InstanceID: instanceID,
+		ServiceID:  serviceID,
+		PlanID:     planID,
+	}
+}
+
+// NewBindRequest returns a request to create the given binding to the given
+// instance of the given service and plan.
+func NewBindRequest(instanceID, bindingID, serviceID, planID string) *BindRequest {
+	return &BindRequest{
+		InstanceID: instanceID,
+		BindingID:  bindingID,
+		ServiceID:  serviceID,
+		PlanID:     planID,
+	}
+}
+
+// NewUnbindRequest returns a request to delete the given binding to the
+// given instance of the given service and plan.
+func NewUnbindRequest(instanceID, bindingID, serviceID, planID string) *UnbindRequest {
+	return &UnbindRequest{
+		InstanceID: instanceID,
+		BindingID:  bindingID,
+		ServiceID:  serviceID,
+		PlanID:     planID,
+	}
+}
+
+// NewLastOperationRequest returns a request to poll the last operation of
+// the given instance, started by a request to the given service and plan
+// which the broker answered with resp, such as a ProvisionResponse or an
+// UpdateInstanceResponse.  The operation key of resp, which must be sent if
+// the broker supplied one, is carried over.  resp may be nil, including a
+// nil *ProvisionResponse or *UpdateInstanceResponse.
+func NewLastOperationRequest(instanceID string, resp OSBAsyncResponse, serviceID, planID *string) *LastOperationRequest {
+	request := &LastOperationRequest{
+		InstanceID: instanceID,
+		ServiceID:  serviceID,
+		PlanID:     planID,
+	}
+	if resp != nil {
+		request.OperationKey = resp.GetOperationKey()
+	}
+	return request
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequestConstructors(t *testing.T) {
+	provisionRequest := NewProvisionRequest(testInstanceID, testServiceID, testPlanID, testOrganizationGUID, testSpaceGUID)
+	if e, a := defaultProvisionRequest(), provisionRequest; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected provision request; expected %+v, got %+v", e, a)
+	}
+	if err := validateProvisionRequest(provisionRequest); err != nil {
+		t.Errorf("unexpected error validating provision request: %v", err)
+	}
+
+	updateInstanceRequest := NewUpdateInstanceRequest(testInstanceID, testServiceID)
+	if e, a := (&UpdateInstanceRequest{InstanceID: testInstanceID, ServiceID: testServiceID}), updateInstanceRequest; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected update instance request; expected %+v, got %+v", e, a)
+	}
+	if err := validateUpdateInstanceRequest(updateInstanceRequest); err != nil {
+		t.Errorf("unexpected error validating update instance request: %v", err)
+	}
+
+	deprovisionRequest := NewDeprovisionRequest(testInstanceID, testServiceID, testPlanID)
+	if e, a := defaultDeprovisionRequest(), deprovisionRequest; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected deprovision request; expected %+v, got %+v", e, a)
+	}
+	if err := validateDeprovisionRequest(deprovisionRequest); err != nil {
+		t.Errorf("unexpected error validating deprovision request: %v", err)
+	}
+
+	bindRequest := NewBindRequest(testInstanceID, testBindingID, testServiceID, testPlanID)
+	if e, a := defaultBindRequest(), bindRequest; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected bind request; expected %+v, got %+v", e, a)
+	}
+	if err := validateBindRequest(bindRequest); err != nil {
+		t.Errorf("unexpected error validating bind request: %v", err)
+	}
+
+	unbindRequest := NewUnbindRequest(testInstanceID, testBindingID, testServiceID, testPlanID)
+	if e, a := defaultUnbindRequest(), unbindRequest; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected unbind request; expected %+v, got %+v", e, a)
+	}
+	if err := validateUnbindRequest(unbindRequest); err != nil {
+		t.Errorf("unexpected error validating unbind request: %v", err)
+	}
+}
+
+func TestNewLastOperationRequest(t *testing.T) {
+	serviceID, planID := testServiceID, testPlanID
+
+	cases := []struct {
+		name     string
+		resp     OSBAsyncResponse
+		expected *OperationKey
+	}{
+		{
+			name:     "provision",
+			resp:     successProvisionResponseAsync(),
+			expected: &testOperation,
+		},
+		{
+			name:     "update",
+			resp:     &UpdateInstanceResponse{Async: true, OperationKey: &testOperation},
+			expected: &testOperation,
+		},
+		{
+			name: "no operation key",
+			resp: &UpdateInstanceResponse{Async: true},
+		},
+		{
+			name: "no response",
+		},
+		{
+			name: "nil provision response",
+			resp: (*ProvisionResponse)(nil),
+		},
+		{
+			name: "nil update response",
+			resp: (*UpdateInstanceResponse)(nil),
+		},
+	}
+
+	for _, tc := range cases {
+		request := NewLastOperationRequest(testInstanceID, tc.resp, &serviceID, &planID)
+		expected := &LastOperationRequest{
+			InstanceID:   testInstanceID,
+			ServiceID:    &serviceID,
+			PlanID:       &planID,
+			OperationKey: tc.expected,
+		}
+		if e, a := expected, request; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -41,15 +42,14 @@ func (c *client) RotateBinding(r *RotateBindingRequest) (*BindResponse, error) {
 
 	fullURL := fmt.Sprintf(bindingURLFmt, c.URL, r.InstanceID, r.BindingID)
 	params := map[string]string{}
-	if r.AcceptsIncomplete {
-		params[AcceptsIncomplete] = "true"
-	}
+	acceptsIncomplete := c.bindingAcceptsIncomplete(r.AcceptsIncomplete)
+	setAcceptsIncomplete(params, acceptsIncomplete, r.SendAcceptsIncompleteFalse)
 
 	requestBody := &rotateBindingRequestBody{
 		PredecessorBindingId: &r.PredecessorBindingID,
 	}
 
-	response, err := c.prepareAndDo(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("RotateBinding", http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -69,10 +69,12 @@ func (c *client) RotateBinding(r *RotateBindingRequest) (*BindResponse, error) {
 		if !c.EnableAlphaFeatures {
 			userResponse.Endpoints = nil
 		}
+		userResponse.Warnings = responseWarnings(response)
+		userResponse.Rotated = true
 
 		return userResponse, nil
 	case http.StatusAccepted:
-		if !r.AcceptsIncomplete {
+		if !acceptsIncomplete {
 			return nil, c.handleFailureResponse(response)
 		}
 
@@ -81,11 +83,9 @@ func (c *client) RotateBinding(r *RotateBindingRequest) (*BindResponse, error) {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
-		var opPtr *OperationKey
-		if responseBodyObj.Operation != nil {
-			opStr := *responseBodyObj.Operation
-			op := OperationKey(opStr)
-			opPtr = &op
+		opPtr, err := c.operationKey(responseBodyObj.asyncOperationFields)
+		if err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
 		userResponse := &BindResponse{
@@ -96,12 +96,17 @@ func (c *client) RotateBinding(r *RotateBindingRequest) (*BindResponse, error) {
 			Endpoints:       responseBodyObj.Endpoints,
 			Metadata:        responseBodyObj.Metadata,
 			OperationKey:    opPtr,
+			Warnings:        responseWarnings(response),
+			Rotated:         true,
 		}
 		if response.StatusCode == http.StatusAccepted {
 			if c.Verbose {
 				klog.Infof("broker %q: received asynchronous response", c.Name)
 			}
 			userResponse.Async = true
+			if c.EnableAlphaFeatures {
+				userResponse.PollDelay = c.pollDelay(response)
+			}
 		}
 		return userResponse, nil
 	default:
@@ -110,17 +115,19 @@ func (c *client) RotateBinding(r *RotateBindingRequest) (*BindResponse, error) {
 }
 
 func validateRotateBindingRequest(request *RotateBindingRequest) error {
+	var errs []error
+
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.BindingID == "" {
-		return required("serviceID")
+		errs = append(errs, required("bindingID"))
 	}
 
 	if request.PredecessorBindingID == "" {
-		return required("predecessorBindingID")
+		errs = append(errs, required("predecessorBindingID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
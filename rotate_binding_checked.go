@@ -0,0 +1,29 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+// RotateBindingChecked rotates a binding using the given client after
+// checking that the given plan, the plan of the binding's instance, supports
+// binding rotation.  This fails fast with a BindingNotRotatableError instead
+// of a broker error after a round trip.  If plan is nil, no check is made.
+func RotateBindingChecked(c Binder, r *RotateBindingRequest, plan *Plan) (*BindResponse, error) {
+	if plan != nil && !plan.IsBindingRotatable() {
+		return nil, BindingNotRotatableError{PlanID: plan.ID}
+	}
+
+	return c.RotateBinding(r)
+}
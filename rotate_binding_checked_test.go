@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"testing"
+)
+
+// rotateCheckedTestClient is a Binder that records RotateBinding calls.
+type rotateCheckedTestClient struct {
+	Binder
+
+	rotations int
+}
+
+func (c *rotateCheckedTestClient) RotateBinding(*RotateBindingRequest) (*BindResponse, error) {
+	c.rotations++
+	return &BindResponse{}, nil
+}
+
+func TestRotateBindingChecked(t *testing.T) {
+	cases := []struct {
+		name        string
+		plan        *Plan
+		expectedErr error
+		expectSent  bool
+	}{
+		{
+			name:       "no plan",
+			expectSent: true,
+		},
+		{
+			name:        "rotatable unset",
+			plan:        &Plan{ID: "unset"},
+			expectedErr: BindingNotRotatableError{PlanID: "unset"},
+		},
+		{
+			name:       "rotatable",
+			plan:       &Plan{ID: "rotatable", BindingRotatable: truePtr()},
+			expectSent: true,
+		},
+		{
+			name:        "not rotatable",
+			plan:        &Plan{ID: "fixed", BindingRotatable: falsePtr()},
+			expectedErr: BindingNotRotatableError{PlanID: "fixed"},
+		},
+	}
+
+	for _, tc := range cases {
+		klient := &rotateCheckedTestClient{}
+
+		_, err := RotateBindingChecked(klient, defaultRotateBindingRequest(), tc.plan)
+		if tc.expectedErr != nil {
+			if err != tc.expectedErr {
+				t.Errorf("%v: expected error %v, got %v", tc.name, tc.expectedErr, err)
+			}
+			if !IsBindingNotRotatableError(err) {
+				t.Errorf("%v: expected a BindingNotRotatableError, got %T", tc.name, err)
+			}
+		} else if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+
+		if e, a := tc.expectSent, klient.rotations == 1; e != a {
+			t.Errorf("%v: expected request sent to be %v", tc.name, e)
+		}
+	}
+}
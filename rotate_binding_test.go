@@ -69,6 +69,7 @@ func successRotatebindingResponse() *BindResponse {
 			"port":     float64(3306),
 			"database": "dbname",
 		},
+		Rotated: true,
 	}
 }
 
@@ -76,6 +77,7 @@ func successRotatebindingResponseAsync() *BindResponse {
 	return &BindResponse{
 		Async:        true,
 		OperationKey: &testOperation,
+		Rotated:      true,
 	}
 }
 
@@ -116,6 +118,15 @@ func TestRotateBinding(t *testing.T) {
 			},
 			expectedResponse: successRotatebindingResponse(),
 		},
+		{
+			name:    "success - ok",
+			version: Version2_17(),
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   successRotateBindingResponseBody,
+			},
+			expectedResponse: successRotatebindingResponse(),
+		},
 		{
 			name:    "success - asynchronous",
 			version: Version2_17(),
@@ -255,3 +266,15 @@ func TestValidateRotateRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRotateBindingRequestReportsAllErrors(t *testing.T) {
+	err := validateRotateBindingRequest(&RotateBindingRequest{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := "instanceID is required\nbindingID is required\npredecessorBindingID is required"
+	if e, a := expected, err.Error(); e != a {
+		t.Errorf("unexpected error; expected %q, got %q", e, a)
+	}
+}
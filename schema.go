@@ -0,0 +1,317 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidateParameters validates the given parameters against the given
+// schema, such as one of the schemas a plan declares for its instances or
+// bindings.  A nil schema, or a schema without parameters, accepts any
+// parameters.
+//
+// Only the commonly used subset of JSON Schema is enforced: type,
+// properties, required, additionalProperties, items, enum, minimum,
+// maximum, minLength, maxLength and pattern.  Other keywords are ignored.
+func ValidateParameters(schema *InputParametersSchema, params map[string]interface{}) error {
+	return validateAgainstSchema(schema, "parameters", params)
+}
+
+// ValidateBindingCredentials validates the credentials returned by a broker
+// for a binding against the given schema, to catch brokers returning
+// credentials that do not match what they declare.  Many brokers do not
+// declare a credentials schema; when the schema is nil or has no
+// parameters, any credentials are accepted.
+func ValidateBindingCredentials(schema *InputParametersSchema, creds map[string]interface{}) error {
+	return validateAgainstSchema(schema, "credentials", creds)
+}
+
+func validateAgainstSchema(schema *InputParametersSchema, path string, value map[string]interface{}) error {
+	if schema == nil || schema.Parameters == nil {
+		return nil
+	}
+
+	root, err := normalizeJSON(schema.Parameters)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %v", err)
+	}
+	rootSchema, ok := root.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid schema: expected an object, got %T", root)
+	}
+
+	normalizedValue, err := normalizeJSON(value)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		normalizedValue = map[string]interface{}{}
+	}
+
+	return validateValue(rootSchema, path, normalizedValue)
+}
+
+// normalizeJSON round-trips v through JSON so that it only contains the
+// types produced by encoding/json.
+func normalizeJSON(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+func validateValue(schema map[string]interface{}, path string, value interface{}) error {
+	if t, ok := schema["type"]; ok {
+		if !matchesType(t, value) {
+			return SchemaValidationError{Path: path, Reason: fmt.Sprintf("expected type %v, got %s", t, jsonTypeOf(value))}
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range enum {
+			if reflect.DeepEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return SchemaValidationError{Path: path, Reason: fmt.Sprintf("value %v is not one of %v", value, enum)}
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return validateObject(schema, path, v)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := validateValue(items, fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case float64:
+		if minimum, ok := schema["minimum"].(float64); ok && v < minimum {
+			return SchemaValidationError{Path: path, Reason: fmt.Sprintf("%v is less than the minimum of %v", v, minimum)}
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && v > maximum {
+			return SchemaValidationError{Path: path, Reason: fmt.Sprintf("%v is greater than the maximum of %v", v, maximum)}
+		}
+	case string:
+		length := float64(len([]rune(v)))
+		if minLength, ok := schema["minLength"].(float64); ok && length < minLength {
+			return SchemaValidationError{Path: path, Reason: fmt.Sprintf("length is less than the minimum of %v", minLength)}
+		}
+		if maxLength, ok := schema["maxLength"].(float64); ok && length > maxLength {
+			return SchemaValidationError{Path: path, Reason: fmt.Sprintf("length is greater than the maximum of %v", maxLength)}
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid schema pattern %q: %v", pattern, err)
+			}
+			if !re.MatchString(v) {
+				return SchemaValidationError{Path: path, Reason: fmt.Sprintf("value does not match pattern %q", pattern)}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateObject(schema map[string]interface{}, path string, value map[string]interface{}) error {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := value[name]; !ok {
+				return SchemaValidationError{Path: path + "." + name, Reason: "is required"}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	keys := make([]string, 0, len(value))
+	for k := range value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		propertyPath := path + "." + k
+		if propertySchema, ok := properties[k].(map[string]interface{}); ok {
+			if err := validateValue(propertySchema, propertyPath, value[k]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return SchemaValidationError{Path: propertyPath, Reason: "is not an allowed property"}
+			}
+		case map[string]interface{}:
+			if err := validateValue(additional, propertyPath, value[k]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchesType returns whether value matches the JSON schema type t, which
+// may be a single type name or a list of type names.
+func matchesType(t interface{}, value interface{}) bool {
+	switch types := t.(type) {
+	case string:
+		return matchesTypeName(types, value)
+	case []interface{}:
+		for _, name := range types {
+			if s, ok := name.(string); ok && matchesTypeName(s, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesTypeName(name string, value interface{}) bool {
+	actual := jsonTypeOf(value)
+	if name == "number" && actual == "integer" {
+		return true
+	}
+	return strings.EqualFold(name, actual)
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// JSONSchema is a typed view of the commonly used keywords of a JSON Schema,
+// for example to generate a form from the schemas a plan declares, without
+// navigating the raw interface{} value.  Keywords it does not model are
+// dropped; InputParametersSchema.Parameters keeps the full schema.
+type JSONSchema struct {
+	// Schema is the URI of the JSON Schema dialect, from the $schema keyword.
+	Schema      string      `json:"$schema,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Type        SchemaTypes `json:"type,omitempty"`
+	// Properties holds the schemas of the properties of an object.
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	// Required lists the names of the properties an object must have.
+	Required []string `json:"required,omitempty"`
+	// Items is the schema of the elements of an array.
+	Items     *JSONSchema   `json:"items,omitempty"`
+	Default   interface{}   `json:"default,omitempty"`
+	Enum      []interface{} `json:"enum,omitempty"`
+	Format    string        `json:"format,omitempty"`
+	Pattern   string        `json:"pattern,omitempty"`
+	Minimum   *float64      `json:"minimum,omitempty"`
+	Maximum   *float64      `json:"maximum,omitempty"`
+	MinLength *int          `json:"minLength,omitempty"`
+	MaxLength *int          `json:"maxLength,omitempty"`
+}
+
+// SchemaTypes holds the value of the type keyword of a JSON Schema, which
+// may either be a single type name or a list of type names.
+type SchemaTypes []string
+
+// UnmarshalJSON accepts both a single type name and a list of type names.
+func (t *SchemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = SchemaTypes{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings: %v", err)
+	}
+	*t = names
+	return nil
+}
+
+// MarshalJSON writes a single type name as a string, as it is usually
+// written, and several as a list.
+func (t SchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Has returns whether name is one of the types.
+func (t SchemaTypes) Has(name string) bool {
+	for _, n := range t {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONSchema decodes the Parameters of the schema into a JSONSchema.  It
+// returns nil if the schema or its Parameters are nil.
+func (s *InputParametersSchema) JSONSchema() (*JSONSchema, error) {
+	if s == nil || s.Parameters == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(s.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	schema := &JSONSchema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testParametersSchema = `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "required": ["size"],
+  "additionalProperties": false,
+  "properties": {
+    "size": {"type": "integer", "minimum": 1, "maximum": 10},
+    "tier": {"type": "string", "enum": ["gold", "silver"]},
+    "name": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 8},
+    "tags": {"type": "array", "items": {"type": "string"}}
+  }
+}`
+
+func testInputParametersSchema(t *testing.T, schema string) *InputParametersSchema {
+	var parameters interface{}
+	if err := json.Unmarshal([]byte(schema), &parameters); err != nil {
+		t.Fatalf("invalid test schema: %v", err)
+	}
+	return &InputParametersSchema{Parameters: parameters}
+}
+
+func TestValidateParameters(t *testing.T) {
+	schema := testInputParametersSchema(t, testParametersSchema)
+
+	cases := []struct {
+		name               string
+		schema             *InputParametersSchema
+		params             map[string]interface{}
+		expectedErrMessage string
+	}{
+		{
+			name:   "nil schema",
+			params: map[string]interface{}{"anything": true},
+		},
+		{
+			name:   "schema without parameters",
+			schema: &InputParametersSchema{},
+			params: map[string]interface{}{"anything": true},
+		},
+		{
+			name:   "valid",
+			schema: schema,
+			params: map[string]interface{}{"size": 3, "tier": "gold", "name": "db", "tags": []string{"a"}},
+		},
+		{
+			name:               "missing required",
+			schema:             schema,
+			params:             map[string]interface{}{"tier": "gold"},
+			expectedErrMessage: "parameters.size: is required",
+		},
+		{
+			name:               "wrong type",
+			schema:             schema,
+			params:             map[string]interface{}{"size": "big"},
+			expectedErrMessage: "parameters.size: expected type integer, got string",
+		},
+		{
+			name:               "not an integer",
+			schema:             schema,
+			params:             map[string]interface{}{"size": 1.5},
+			expectedErrMessage: "parameters.size: expected type integer, got number",
+		},
+		{
+			name:               "above maximum",
+			schema:             schema,
+			params:             map[string]interface{}{"size": 11},
+			expectedErrMessage: "parameters.size: 11 is greater than the maximum of 10",
+		},
+		{
+			name:               "not in enum",
+			schema:             schema,
+			params:             map[string]interface{}{"size": 1, "tier": "bronze"},
+			expectedErrMessage: "parameters.tier: value bronze is not one of [gold silver]",
+		},
+		{
+			name:               "pattern mismatch",
+			schema:             schema,
+			params:             map[string]interface{}{"size": 1, "name": "DB"},
+			expectedErrMessage: `parameters.name: value does not match pattern "^[a-z]+$"`,
+		},
+		{
+			name:               "invalid array item",
+			schema:             schema,
+			params:             map[string]interface{}{"size": 1, "tags": []interface{}{"a", 2}},
+			expectedErrMessage: "parameters.tags[1]: expected type string, got integer",
+		},
+		{
+			name:               "additional property",
+			schema:             schema,
+			params:             map[string]interface{}{"size": 1, "extra": true},
+			expectedErrMessage: "parameters.extra: is not an allowed property",
+		},
+	}
+
+	for _, tc := range cases {
+		err := ValidateParameters(tc.schema, tc.params)
+		if tc.expectedErrMessage == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expectedErrMessage {
+			t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+		}
+		if !IsSchemaValidationError(err) {
+			t.Errorf("%v: expected a SchemaValidationError, got %T", tc.name, err)
+		}
+	}
+}
+
+func TestValidateBindingCredentials(t *testing.T) {
+	schema := testInputParametersSchema(t, `{
+  "type": "object",
+  "required": ["uri", "port"],
+  "properties": {
+    "uri": {"type": "string"},
+    "port": {"type": "integer"}
+  }
+}`)
+
+	cases := []struct {
+		name               string
+		schema             *InputParametersSchema
+		creds              map[string]interface{}
+		expectedErrMessage string
+	}{
+		{
+			name:  "no schema declared",
+			creds: map[string]interface{}{"password": "secret"},
+		},
+		{
+			name:   "valid credentials",
+			schema: schema,
+			creds:  map[string]interface{}{"uri": "mysql://host", "port": float64(3306), "password": "secret"},
+		},
+		{
+			name:               "missing credential",
+			schema:             schema,
+			creds:              map[string]interface{}{"uri": "mysql://host"},
+			expectedErrMessage: "credentials.port: is required",
+		},
+	}
+
+	for _, tc := range cases {
+		err := ValidateBindingCredentials(tc.schema, tc.creds)
+		if tc.expectedErrMessage == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.expectedErrMessage {
+			t.Errorf("%v: expected error %q, got %v", tc.name, tc.expectedErrMessage, err)
+		}
+	}
+}
+
+func TestInputParametersSchemaJSONSchema(t *testing.T) {
+	floatPtr := func(f float64) *float64 { return &f }
+	intPtr := func(i int) *int { return &i }
+
+	schema, err := testInputParametersSchema(t, `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "title": "Create a database",
+  "type": "object",
+  "required": ["size"],
+  "additionalProperties": false,
+  "properties": {
+    "size": {"type": "integer", "description": "Size in GB", "minimum": 1, "maximum": 10, "default": 5},
+    "tier": {"type": "string", "enum": ["gold", "silver"]},
+    "name": {"type": ["string", "null"], "pattern": "^[a-z]+$", "maxLength": 8},
+    "tags": {"type": "array", "items": {"type": "string", "format": "hostname"}}
+  }
+}`).JSONSchema()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &JSONSchema{
+		Schema:   "http://json-schema.org/draft-04/schema#",
+		Title:    "Create a database",
+		Type:     SchemaTypes{"object"},
+		Required: []string{"size"},
+		Properties: map[string]*JSONSchema{
+			"size": {
+				Type:        SchemaTypes{"integer"},
+				Description: "Size in GB",
+				Minimum:     floatPtr(1),
+				Maximum:     floatPtr(10),
+				Default:     float64(5),
+			},
+			"tier": {
+				Type: SchemaTypes{"string"},
+				Enum: []interface{}{"gold", "silver"},
+			},
+			"name": {
+				Type:      SchemaTypes{"string", "null"},
+				Pattern:   "^[a-z]+$",
+				MaxLength: intPtr(8),
+			},
+			"tags": {
+				Type:  SchemaTypes{"array"},
+				Items: &JSONSchema{Type: SchemaTypes{"string"}, Format: "hostname"},
+			},
+		},
+	}
+	if e, a := expected, schema; !reflect.DeepEqual(e, a) {
+		t.Errorf("unexpected schema;\n\nexpected: %+v\n\ngot:      %+v", e, a)
+	}
+	if !schema.Properties["name"].Type.Has("null") || schema.Properties["size"].Type.Has("string") {
+		t.Errorf("unexpected types: %v, %v", schema.Properties["name"].Type, schema.Properties["size"].Type)
+	}
+
+	b, err := json.Marshal(schema.Properties["tier"])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := `{"type":"string","enum":["gold","silver"]}`, string(b); e != a {
+		t.Errorf("expected %s, got %s", e, a)
+	}
+
+	if schema, err := (&InputParametersSchema{}).JSONSchema(); schema != nil || err != nil {
+		t.Errorf("expected no schema and no error, got %v, %v", schema, err)
+	}
+	if _, err := testInputParametersSchema(t, `{"type": 1}`).JSONSchema(); err == nil {
+		t.Error("expected an error for an invalid type")
+	}
+}
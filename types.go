@@ -36,9 +36,9 @@ type Service struct {
 	// A list of permissions the user must give instances of this service.
 	// CF-specific. Current valid values are:
 	//
-	// - syslog_drain
-	// - route_forwarding
-	// - volume_mount
+	// - syslog_drain (RequiresSyslogDrain)
+	// - route_forwarding (RequiresRouteForwarding)
+	// - volume_mount (RequiresVolumeMount)
 	//
 	// See the Open Service Broker API spec for information on permissions.
 	Requires []string `json:"requires,omitempty"`
@@ -143,6 +143,16 @@ type MaintenanceInfo struct {
 	Description string `json:"description,omitempty"`
 }
 
+// PlanCost is a cost of a plan, as conventionally listed by Cloud Foundry
+// catalogs under the "costs" key of the plan's metadata.
+type PlanCost struct {
+	// Amount maps currency codes, such as "usd", to the cost in that
+	// currency.
+	Amount map[string]float64 `json:"amount"`
+	// Unit is the unit the cost is charged for, such as "MONTHLY".
+	Unit string `json:"unit"`
+}
+
 type ServiceInstanceMetadata struct {
 	Labels     map[string]interface{} `json:"labels,omitempty"`
 	Attributes map[string]interface{} `json:"attributes,omitempty"`
@@ -208,6 +218,20 @@ type OriginatingIdentity struct {
 // CatalogResponse is sent as the response to catalog requests.
 type CatalogResponse struct {
 	Services []Service `json:"services"`
+	// Metadata is a blob of information about the catalog as a whole, such
+	// as a catalog version or last-updated timestamp, that some brokers
+	// return. Not part of the Open Service Broker API specification.
+	// Optional.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// BrokerAPIVersion is the API version advertised by the broker in the
+	// X-Broker-API-Version header of the catalog response, if any.  Brokers
+	// are not required to send it.  If it is lower than the API version of
+	// the client, a warning is logged when the client is Verbose.  Not part
+	// of the response body.
+	BrokerAPIVersion string `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // ProvisionRequest represents a request to provision a new instance of a
@@ -215,7 +239,9 @@ type CatalogResponse struct {
 type ProvisionRequest struct {
 	// InstanceID is the ID of the new instance to provision. The Open
 	// Service Broker API specification recommends using a GUID for this
-	// field.
+	// field. If empty and the client is configured with GenerateInstanceIDs,
+	// a GUID is generated and returned in the InstanceID of the response;
+	// this field is left empty.
 	InstanceID string `json:"instance_id"`
 	// AcceptsIncomplete indicates whether the client can accept asynchronous
 	// provisioning. If the broker cannot fulfill a request synchronously and
@@ -223,6 +249,10 @@ type ProvisionRequest struct {
 	// A broker may choose to response to a request with AcceptsIncomplete set
 	// to true either synchronously or asynchronously.
 	AcceptsIncomplete bool `json:"accepts_incomplete"`
+	// SendAcceptsIncompleteFalse, if set, sends accepts_incomplete=false when
+	// AcceptsIncomplete is false instead of omitting the parameter. It exists
+	// for brokers that reject requests lacking the parameter.
+	SendAcceptsIncompleteFalse bool `json:"-"`
 	// ServiceID is the ID of the service to provision a new instance of.
 	ServiceID string `json:"service_id"`
 	// PlanID is the ID of the plan to use for the new instance.
@@ -234,8 +264,13 @@ type ProvisionRequest struct {
 	// organization. CF-specific.
 	SpaceGUID string `json:"space_guid"`
 	// Parameters is a set of configuration options for the service instance.
-	// Optional.
+	// Optional.  SetParameters sets them from a typed value.
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// SendEmptyParameters, if set, sends an empty parameters object when
+	// Parameters is empty, nil or not, instead of omitting the field.  It
+	// exists for brokers that treat explicitly empty parameters differently
+	// from absent ones.
+	SendEmptyParameters bool `json:"-"`
 	// Context requires a client API version >= 2.12.
 	//
 	// Context is platform-specific contextual information under which the
@@ -250,18 +285,37 @@ type ProvisionRequest struct {
 
 // ProvisionResponse is sent in response to a provision call.
 type ProvisionResponse struct {
+	// InstanceID is the ID of the provisioned instance: that of the request,
+	// or the GUID generated by the client if the request had none.
+	InstanceID string `json:"-"`
 	// Async indicates whether the broker is handling the provision request
 	// asynchronously.
 	Async bool `json:"async"`
 	// DashboardURL is the URL of a web-based management user interface for
 	// the service instance.
 	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Dashboards holds the dashboards returned by brokers using the extended
+	// form of dashboard_url, a dashboard object or a list of dashboards.  In
+	// that case DashboardURL holds the URL of the first dashboard.  As for
+	// DashboardURL, and unlike in UpdateInstanceResponse, no minimum client
+	// API version is required.
+	Dashboards []Dashboard `json:"-"`
 	// Metadata is an optional object containing metadata for the service
 	// instance.
 	Metadata *ServiceInstanceMetadata `json:"metadata,omitempty"`
 	// OperationKey is an extra identifier supplied by the broker to identify
 	// asynchronous operations.
 	OperationKey *OperationKey `json:"operation,omitempty"`
+	// PollDelay requires alpha features to be enabled.
+	//
+	// PollDelay is how long the broker asked, through the Retry-After header
+	// of its asynchronous response, the client to wait before the first poll
+	// of the operation.
+	PollDelay *time.Duration `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, with
+	// which the broker reports non-fatal issues such as deprecations.  They
+	// are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // OperationKey is an extra identifier from the broker in order to provide extra
@@ -279,6 +333,10 @@ type UpdateInstanceRequest struct {
 	// reject the request. A broker may choose to response to a request with
 	// AcceptsIncomplete set to true either synchronously or asynchronously.
 	AcceptsIncomplete bool `json:"accepts_incomplete"`
+	// SendAcceptsIncompleteFalse, if set, sends accepts_incomplete=false when
+	// AcceptsIncomplete is false instead of omitting the parameter. It exists
+	// for brokers that reject requests lacking the parameter.
+	SendAcceptsIncompleteFalse bool `json:"-"`
 	// ServiceID is the ID of the service the instance is provisioned from.
 	ServiceID string `json:"service_id"`
 	// PlanID is the ID the plan to update the instance to. The service must
@@ -322,6 +380,12 @@ type PreviousValues struct {
 	// in the top-level field context. ID of the space specified for the service
 	// instance. If present, MUST be a non-empty string.
 	SpaceID string `json:"space_id,omitempty"`
+	// MaintenanceInfo requires alpha features flag to be enabled.
+	//
+	// MaintenanceInfo is the maintenance information of the plan of the
+	// instance prior to the update, so that the broker can tell which
+	// maintenance to apply.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
 }
 
 // UpdateInstanceResponse represents a broker's response to an update instance
@@ -335,12 +399,27 @@ type UpdateInstanceResponse struct {
 	// DashboardURL is the URL of a web-based management user interface for
 	// the service instance.
 	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Dashboards requires a client API version >= 2.14.
+	//
+	// Dashboards holds the dashboards returned by brokers using the extended
+	// form of dashboard_url, a dashboard object or a list of dashboards.  In
+	// that case DashboardURL holds the URL of the first dashboard.
+	Dashboards []Dashboard `json:"-"`
 	// Metadata is an optional object containing metadata for the service
 	// instance.
 	Metadata *ServiceInstanceMetadata `json:"metadata,omitempty"`
 	// OperationKey is an extra identifier supplied by the broker to identify
 	// asynchronous operations.
 	OperationKey *OperationKey `json:"operation,omitempty"`
+	// PollDelay requires alpha features to be enabled.
+	//
+	// PollDelay is how long the broker asked, through the Retry-After header
+	// of its asynchronous response, the client to wait before the first poll
+	// of the operation.
+	PollDelay *time.Duration `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // DeprovisionRequest represents a request to deprovision an instance of a
@@ -354,10 +433,28 @@ type DeprovisionRequest struct {
 	// A broker may choose to response to a request with AcceptsIncomplete set
 	// to true either synchronously or asynchronously.
 	AcceptsIncomplete bool `json:"accepts_incomplete"`
+	// SendAcceptsIncompleteFalse, if set, sends accepts_incomplete=false when
+	// AcceptsIncomplete is false instead of omitting the parameter. It exists
+	// for brokers that reject requests lacking the parameter.
+	SendAcceptsIncompleteFalse bool `json:"-"`
+	// SendEmptyBody, if set, sends an empty JSON object as the request body,
+	// with a JSON Content-Type, instead of no body at all. Some brokers
+	// reject a DELETE without it.
+	SendEmptyBody bool `json:"-"`
 	// ServiceID is the ID of the service the instance is provisioned from.
 	ServiceID string `json:"service_id"`
 	// PlanID is the ID of the plan the instance is provisioned from.
 	PlanID string `json:"plan_id"`
+	// Parameters requires a client API version >= 2.17.
+	//
+	// Parameters is a set of configuration options for the deprovision
+	// operation. Optional. The Open Service Broker API defines no deprovision
+	// parameters: this is an extension, accepted by some brokers, which the
+	// client only sends with API version >= 2.17 and otherwise refuses with
+	// an OperationNotAllowedError.  Since deprovision is a DELETE, which
+	// carries no request body, the parameters are JSON-encoded and sent as
+	// the value of the 'parameters' query parameter.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 	// OriginatingIdentity requires a client API version >= 2.13.
 	//
 	// OriginatingIdentity is the identity on the platform of the user making
@@ -386,11 +483,18 @@ type GetInstanceResponse struct {
 	// DashboardURL is the URL of a web-based management user interface for
 	// the service instance.
 	DashboardURL string `json:"dashboard_url,omitempty"`
+	// Dashboards holds the dashboards returned by brokers using the extended
+	// form of dashboard_url, a dashboard object or a list of dashboards.  In
+	// that case DashboardURL holds the URL of the first dashboard.
+	Dashboards []Dashboard `json:"-"`
 	// Metadata is an optional object containing metadata for the service
 	// instance.
 	Metadata ServiceInstanceMetadata `json:"metadata,omitempty"`
 	// Parameters is a set of configuration options for the instance.
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // DeprovisionResponse represents a broker's response to a deprovision request.
@@ -401,6 +505,15 @@ type DeprovisionResponse struct {
 	// OperationKey is an extra identifier supplied by the broker to identify
 	// asynchronous operations.
 	OperationKey *OperationKey `json:"operation,omitempty"`
+	// PollDelay requires alpha features to be enabled.
+	//
+	// PollDelay is how long the broker asked, through the Retry-After header
+	// of its asynchronous response, the client to wait before the first poll
+	// of the operation.
+	PollDelay *time.Duration `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // LastOperationRequest represents a request to a broker to give the state of
@@ -463,6 +576,9 @@ type LastOperationResponse struct {
 	// API >= 1.15 indicating how long the client should wait before retrying
 	// polling for the operation result again.
 	PollDelay *time.Duration `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // LastOperationState is a typedef representing the state of an ongoing
@@ -498,6 +614,10 @@ type BindRequest struct {
 	// broker may choose to response to a request with AcceptsIncomplete set to
 	// true either synchronously or asynchronously.
 	AcceptsIncomplete bool `json:"accepts_incomplete"`
+	// SendAcceptsIncompleteFalse, if set, sends accepts_incomplete=false when
+	// AcceptsIncomplete is false instead of omitting the parameter. It exists
+	// for brokers that reject requests lacking the parameter.
+	SendAcceptsIncompleteFalse bool `json:"-"`
 	// ServiceID is the ID of the service the instance was provisioned from.
 	ServiceID string `json:"service_id"`
 	// PlanID is the ID of the plan the instance was provisioned from.
@@ -595,6 +715,19 @@ type BindResponse struct {
 	// OperationKey is an extra identifier supplied by the broker to identify
 	// asynchronous operations.
 	OperationKey *OperationKey `json:"operation,omitempty"`
+	// PollDelay requires alpha features to be enabled.
+	//
+	// PollDelay is how long the broker asked, through the Retry-After header
+	// of its asynchronous response, the client to wait before the first poll
+	// of the operation.
+	PollDelay *time.Duration `json:"-"`
+	// Rotated indicates whether the response was returned by RotateBinding
+	// rather than Bind. It is set by the client and never read from or
+	// written to the broker.
+	Rotated bool `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // UnbindRequest represents a request to unbind a particular binding.
@@ -611,6 +744,14 @@ type UnbindRequest struct {
 	// broker may choose to response to a request with AcceptsIncomplete set to
 	// true either synchronously or asynchronously.
 	AcceptsIncomplete bool `json:"accepts_incomplete"`
+	// SendAcceptsIncompleteFalse, if set, sends accepts_incomplete=false when
+	// AcceptsIncomplete is false instead of omitting the parameter. It exists
+	// for brokers that reject requests lacking the parameter.
+	SendAcceptsIncompleteFalse bool `json:"-"`
+	// SendEmptyBody, if set, sends an empty JSON object as the request body,
+	// with a JSON Content-Type, instead of no body at all. Some brokers
+	// reject a DELETE without it.
+	SendEmptyBody bool `json:"-"`
 	// ServiceID is the ID of the service the instance was provisioned from.
 	ServiceID string `json:"service_id"`
 	// PlanID is the ID of the plan the instance was provisioned from.
@@ -634,6 +775,15 @@ type UnbindResponse struct {
 	// OperationKey is an extra identifier supplied by the broker to identify
 	// asynchronous operations.
 	OperationKey *OperationKey `json:"operation,omitempty"`
+	// PollDelay requires alpha features to be enabled.
+	//
+	// PollDelay is how long the broker asked, through the Retry-After header
+	// of its asynchronous response, the client to wait before the first poll
+	// of the operation.
+	PollDelay *time.Duration `json:"-"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 // GetBindingRequest represents a request to do a GET on a particular binding.
@@ -642,9 +792,11 @@ type GetBindingRequest struct {
 	InstanceID string `json:"instance_id"`
 	// BindingID is the ID of the binding to delete.
 	BindingID string `json:"binding_id"`
-	// Service ID is the ID of the Service ID
+	// ServiceID is the ID of the service of the instance.  If set, it is
+	// sent as the service_id query parameter.
 	ServiceID string `json:"service_id"`
-	// Plan ID is the id of the Plan ID
+	// PlanID is the ID of the plan of the instance.  If set, it is sent as
+	// the plan_id query parameter.
 	PlanID string `json:"plan_id"`
 }
 
@@ -676,6 +828,9 @@ type GetBindingResponse struct {
 	// Metadata is an optional object containing metadata for the service
 	// binding.
 	Metadata *BindingMetadata `json:"metadata,omitempty"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
 }
 
 type RotateBindingRequest struct {
@@ -689,6 +844,10 @@ type RotateBindingRequest struct {
 	// reject the request. A broker may choose to response to a request with
 	// AcceptsIncomplete set to true either synchronously or asynchronously.
 	AcceptsIncomplete bool `json:"accepts_incomplete"`
+	// SendAcceptsIncompleteFalse, if set, sends accepts_incomplete=false when
+	// AcceptsIncomplete is false instead of omitting the parameter. It exists
+	// for brokers that reject requests lacking the parameter.
+	SendAcceptsIncompleteFalse bool `json:"-"`
 	// PredecessorBindingId is the ID of the non-expired binding of the same
 	// service instance.
 	PredecessorBindingID string `json:"predecessor_binding_id"`
@@ -703,6 +862,26 @@ type GetStatusRequest struct{}
 
 type GetStatusResponse struct {
 	Status string `json:"status"`
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.  They are also logged if the client is Verbose.
+	Warnings []string `json:"-"`
+}
+
+// ResponseMeta holds information about the exchange of a request and response
+// with a broker, as opposed to the content of the response.  It is exposed by
+// RequestIdentityMismatchError, that is only when RequestIdentityVerification
+// is RequestIdentityVerificationStrict; the Warnings are also exposed by the
+// responses themselves.
+type ResponseMeta struct {
+	// SentRequestIdentity is the X-Broker-API-Request-Identity header value
+	// sent to the broker.
+	SentRequestIdentity string
+	// ReceivedRequestIdentity is the X-Broker-API-Request-Identity header
+	// value echoed by the broker, if any.
+	ReceivedRequestIdentity string
+	// Warnings holds the values of the Warning headers of the response, if
+	// any.
+	Warnings []string
 }
 
 // OSBAsyncResponse defines the common behavior for asynchronous responses
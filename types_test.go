@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSliceMarshaling(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			name:     "catalog with nil services",
+			value:    CatalogResponse{},
+			expected: `{"services":[]}`,
+		},
+		{
+			name:     "catalog with empty services",
+			value:    &CatalogResponse{Services: []Service{}},
+			expected: `{"services":[]}`,
+		},
+		{
+			name:     "service with nil plans and tags",
+			value:    Service{ID: "id", Name: "name"},
+			expected: `{"id":"id","name":"name","description":"","bindable":false,"plans":[]}`,
+		},
+		{
+			name:     "service with empty tags",
+			value:    Service{ID: "id", Name: "name", Tags: []string{}, Plans: []Plan{}},
+			expected: `{"id":"id","name":"name","description":"","bindable":false,"plans":[]}`,
+		},
+		{
+			name:     "service with tags",
+			value:    Service{ID: "id", Name: "name", Tags: []string{"a", "b"}},
+			expected: `{"id":"id","name":"name","description":"","tags":["a","b"],"bindable":false,"plans":[]}`,
+		},
+		{
+			name:     "endpoint with nil ports",
+			value:    Endpoint{Host: "host"},
+			expected: `{"host":"host","ports":[]}`,
+		},
+		{
+			name:     "endpoint with ports",
+			value:    Endpoint{Host: "host", Ports: []uint16{80, 443}},
+			expected: `{"host":"host","ports":[80,443]}`,
+		},
+		{
+			name:     "binding with nil endpoints",
+			value:    GetBindingResponse{},
+			expected: `{}`,
+		},
+		{
+			name:     "binding with empty endpoints",
+			value:    GetBindingResponse{Endpoints: Ptr([]Endpoint{}), VolumeMounts: Ptr([]VolumeMount{})},
+			expected: `{"volume_mounts":[],"endpoints":[]}`,
+		},
+		{
+			name:     "binding with endpoints",
+			value:    GetBindingResponse{Endpoints: Ptr([]Endpoint{{Host: "host"}})},
+			expected: `{"endpoints":[{"host":"host","ports":[]}]}`,
+		},
+	}
+	for _, tc := range cases {
+		data, err := json.Marshal(tc.value)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if e, a := tc.expected, string(data); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
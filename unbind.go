@@ -17,6 +17,7 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -24,7 +25,7 @@ import (
 )
 
 type unbindSuccessResponseBody struct {
-	Operation *string `json:"operation"`
+	asyncOperationFields
 }
 
 func (c *client) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
@@ -44,11 +45,10 @@ func (c *client) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
 	params := map[string]string{}
 	params[VarKeyServiceID] = r.ServiceID
 	params[VarKeyPlanID] = r.PlanID
-	if r.AcceptsIncomplete {
-		params[AcceptsIncomplete] = "true"
-	}
+	acceptsIncomplete := c.bindingAcceptsIncomplete(r.AcceptsIncomplete)
+	setAcceptsIncomplete(params, acceptsIncomplete, r.SendAcceptsIncompleteFalse)
 
-	response, err := c.prepareAndDo(http.MethodDelete, fullURL, params, nil, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("Unbind", http.MethodDelete, fullURL, params, deleteRequestBody(r.SendEmptyBody), r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +64,14 @@ func (c *client) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
 		if err := c.unmarshalResponse(response, userResponse); err != nil {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
+		userResponse.Warnings = responseWarnings(response)
 
 		return userResponse, nil
+	case http.StatusNoContent:
+		// Some brokers answer with no body at all.
+		return &UnbindResponse{Warnings: responseWarnings(response)}, nil
 	case http.StatusAccepted:
-		if !r.AcceptsIncomplete {
+		if !acceptsIncomplete {
 			return nil, c.handleFailureResponse(response)
 		}
 
@@ -76,15 +80,17 @@ func (c *client) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
-		var opPtr *OperationKey
-		if responseBodyObj.Operation != nil {
-			opStr := *responseBodyObj.Operation
-			op := OperationKey(opStr)
-			opPtr = &op
+		opPtr, err := c.operationKey(responseBodyObj.asyncOperationFields)
+		if err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
 		userResponse := &UnbindResponse{
 			OperationKey: opPtr,
+			Warnings:     responseWarnings(response),
+		}
+		if c.EnableAlphaFeatures {
+			userResponse.PollDelay = c.pollDelay(response)
 		}
 		if response.StatusCode == http.StatusAccepted {
 			if c.Verbose {
@@ -100,21 +106,23 @@ func (c *client) Unbind(r *UnbindRequest) (*UnbindResponse, error) {
 }
 
 func validateUnbindRequest(request *UnbindRequest) error {
+	var errs []error
+
 	if request.BindingID == "" {
-		return required("bindingID")
+		errs = append(errs, required("bindingID"))
 	}
 
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.ServiceID == "" {
-		return required("serviceID")
+		errs = append(errs, required("serviceID"))
 	}
 
 	if request.PlanID == "" {
-		return required("planID")
+		errs = append(errs, required("planID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
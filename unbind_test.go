@@ -84,6 +84,46 @@ func TestUnbind(t *testing.T) {
 			},
 			expectedResponse: successUnbindResponse(),
 		},
+		{
+			name: "success - no content",
+			httpReaction: httpReaction{
+				status: http.StatusNoContent,
+			},
+			expectedResponse: successUnbindResponse(),
+		},
+		{
+			name:    "success - no content to asynchronous request",
+			version: LatestAPIVersion(),
+			request: defaultAsyncUnbindRequest(),
+			httpChecks: httpChecks{
+				params: map[string]string{
+					AcceptsIncomplete: "true",
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusNoContent,
+			},
+			expectedResponse: successUnbindResponse(),
+		},
+		{
+			name: "success - empty body",
+			request: func() *UnbindRequest {
+				r := defaultUnbindRequest()
+				r.SendEmptyBody = true
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: "{}",
+				headers: map[string]string{
+					contentType: jsonType,
+				},
+			},
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   successUnbindResponseBody,
+			},
+			expectedResponse: successUnbindResponse(),
+		},
 		{
 			name:    "success - asynchronous",
 			version: LatestAPIVersion(),
@@ -17,6 +17,7 @@ limitations under the License.
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -32,9 +33,9 @@ type updateInstanceRequestBody struct {
 }
 
 type updateInstanceResponseBody struct {
-	DashboardURL *string                  `json:"dashboard_url"`
+	DashboardURL dashboardField           `json:"dashboard_url"`
 	Metadata     *ServiceInstanceMetadata `json:"metadata,omitempty"`
-	Operation    *string                  `json:"operation"`
+	asyncOperationFields
 }
 
 func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceResponse, error) {
@@ -42,11 +43,12 @@ func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceRespon
 		return nil, err
 	}
 
+	c.warnDeprecated(previousValuesDeprecations(r.PreviousValues))
+
 	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.URL, r.InstanceID)
 	params := map[string]string{}
-	if r.AcceptsIncomplete {
-		params[AcceptsIncomplete] = "true"
-	}
+	acceptsIncomplete := c.acceptsIncomplete(r.AcceptsIncomplete)
+	setAcceptsIncomplete(params, acceptsIncomplete, r.SendAcceptsIncompleteFalse)
 
 	requestBody := &updateInstanceRequestBody{
 		ServiceID:      r.ServiceID,
@@ -55,11 +57,17 @@ func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceRespon
 		PreviousValues: r.PreviousValues,
 	}
 
+	if !c.EnableAlphaFeatures && r.PreviousValues != nil && r.PreviousValues.MaintenanceInfo != nil {
+		previousValues := *r.PreviousValues
+		previousValues.MaintenanceInfo = nil
+		requestBody.PreviousValues = &previousValues
+	}
+
 	if c.APIVersion.AtLeast(Version2_12()) {
-		requestBody.Context = r.Context
+		requestBody.Context = c.requestContext(r.Context)
 	}
 
-	response, err := c.prepareAndDo(http.MethodPatch, fullURL, params, requestBody, r.OriginatingIdentity)
+	response, err := c.prepareAndDo("UpdateInstance", http.MethodPatch, fullURL, params, requestBody, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -80,14 +88,16 @@ func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceRespon
 			Async:        false,
 			OperationKey: nil,
 			Metadata:     responseBodyObj.Metadata,
+			Warnings:     responseWarnings(response),
 		}
 		if c.APIVersion.AtLeast(Version2_14()) {
-			userResponse.DashboardURL = responseBodyObj.DashboardURL
+			userResponse.DashboardURL = responseBodyObj.DashboardURL.URL
+			userResponse.Dashboards = responseBodyObj.DashboardURL.Dashboards
 		}
 
 		return userResponse, nil
 	case http.StatusAccepted:
-		if !r.AcceptsIncomplete {
+		if !acceptsIncomplete {
 			// If the client did not signify that it could handle asynchronous
 			// operations, a '202 Accepted' response should be treated as an error.
 			return nil, c.handleFailureResponse(response)
@@ -98,20 +108,23 @@ func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceRespon
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
-		var opPtr *OperationKey
-		if responseBodyObj.Operation != nil {
-			opStr := *responseBodyObj.Operation
-			op := OperationKey(opStr)
-			opPtr = &op
+		opPtr, err := c.operationKey(responseBodyObj.asyncOperationFields)
+		if err != nil {
+			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
 		userResponse := &UpdateInstanceResponse{
 			Async:        true,
 			OperationKey: opPtr,
 			Metadata:     responseBodyObj.Metadata,
+			Warnings:     responseWarnings(response),
+		}
+		if c.EnableAlphaFeatures {
+			userResponse.PollDelay = c.pollDelay(response)
 		}
 		if c.APIVersion.AtLeast(Version2_14()) {
-			userResponse.DashboardURL = responseBodyObj.DashboardURL
+			userResponse.DashboardURL = responseBodyObj.DashboardURL.URL
+			userResponse.Dashboards = responseBodyObj.DashboardURL.Dashboards
 		}
 
 		// TODO: fix op key handling
@@ -123,13 +136,15 @@ func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceRespon
 }
 
 func validateUpdateInstanceRequest(request *UpdateInstanceRequest) error {
+	var errs []error
+
 	if request.InstanceID == "" {
-		return required("instanceID")
+		errs = append(errs, required("instanceID"))
 	}
 
 	if request.ServiceID == "" {
-		return required("serviceID")
+		errs = append(errs, required("serviceID"))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
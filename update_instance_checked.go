@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+)
+
+// UpdateInstanceChecked updates an instance using the given client after
+// checking against the given catalog that a plan change, if requested, is
+// supported.  This fails fast with a PlanNotUpdatableError instead of
+// a broker error after a round trip.
+//
+// The current plan of the instance is taken from the request's
+// PreviousValues, if set, so that plan-level PlanUpdateable overrides are
+// honored; otherwise only the service-level PlanUpdatable field is consulted.
+// If catalog is nil, no check is made.
+func UpdateInstanceChecked(c Provisioner, r *UpdateInstanceRequest, catalog *CatalogResponse) (*UpdateInstanceResponse, error) {
+	if catalog != nil && r.ChangeKind().Has(PlanChange) {
+		if err := checkPlanUpdatable(r, catalog); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.UpdateInstance(r)
+}
+
+func checkPlanUpdatable(r *UpdateInstanceRequest, catalog *CatalogResponse) error {
+	service := catalog.FindService(r.ServiceID)
+	if service == nil {
+		return fmt.Errorf("service %q not found in catalog", r.ServiceID)
+	}
+
+	var currentPlanID string
+	if r.PreviousValues != nil {
+		currentPlanID = r.PreviousValues.PlanID
+	}
+
+	// Requesting the current plan is not a plan change.
+	if currentPlanID != "" && currentPlanID == *r.PlanID {
+		return nil
+	}
+
+	plan := Plan{}
+	if currentPlan := service.FindPlan(currentPlanID); currentPlan != nil {
+		plan = *currentPlan
+	}
+
+	if !service.ResolvePlanUpdatable(plan) {
+		return PlanNotUpdatableError{ServiceID: r.ServiceID, PlanID: currentPlanID}
+	}
+
+	return nil
+}
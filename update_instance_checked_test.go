@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"testing"
+)
+
+// updateCheckedTestClient is a Provisioner that records UpdateInstance calls.
+type updateCheckedTestClient struct {
+	Provisioner
+
+	updates int
+}
+
+func (c *updateCheckedTestClient) UpdateInstance(*UpdateInstanceRequest) (*UpdateInstanceResponse, error) {
+	c.updates++
+	return &UpdateInstanceResponse{}, nil
+}
+
+func TestUpdateInstanceChecked(t *testing.T) {
+	catalog := &CatalogResponse{
+		Services: []Service{
+			{
+				ID:            "updatable-service",
+				PlanUpdatable: truePtr(),
+				Plans: []Plan{
+					{ID: "small"},
+					{ID: "pinned", PlanUpdateable: falsePtr()},
+				},
+			},
+			{
+				ID: "fixed-service",
+				Plans: []Plan{
+					{ID: "small"},
+					{ID: "flexible", PlanUpdateable: truePtr()},
+				},
+			},
+		},
+	}
+
+	large := "large"
+	small := "small"
+
+	cases := []struct {
+		name        string
+		request     *UpdateInstanceRequest
+		expectedErr error
+		expectSent  bool
+	}{
+		{
+			name:       "parameter change on fixed service",
+			request:    &UpdateInstanceRequest{ServiceID: "fixed-service", Parameters: map[string]interface{}{"a": 1}},
+			expectSent: true,
+		},
+		{
+			name:       "plan change on updatable service",
+			request:    &UpdateInstanceRequest{ServiceID: "updatable-service", PlanID: &large},
+			expectSent: true,
+		},
+		{
+			name:        "plan change on fixed service",
+			request:     &UpdateInstanceRequest{ServiceID: "fixed-service", PlanID: &large},
+			expectedErr: PlanNotUpdatableError{ServiceID: "fixed-service"},
+		},
+		{
+			name: "plan change from pinned plan",
+			request: &UpdateInstanceRequest{
+				ServiceID:      "updatable-service",
+				PlanID:         &large,
+				PreviousValues: &PreviousValues{PlanID: "pinned"},
+			},
+			expectedErr: PlanNotUpdatableError{ServiceID: "updatable-service", PlanID: "pinned"},
+		},
+		{
+			name: "plan change from flexible plan",
+			request: &UpdateInstanceRequest{
+				ServiceID:      "fixed-service",
+				PlanID:         &large,
+				PreviousValues: &PreviousValues{PlanID: "flexible"},
+			},
+			expectSent: true,
+		},
+		{
+			name: "same plan on fixed service",
+			request: &UpdateInstanceRequest{
+				ServiceID:      "fixed-service",
+				PlanID:         &small,
+				PreviousValues: &PreviousValues{PlanID: "small"},
+			},
+			expectSent: true,
+		},
+	}
+
+	for _, tc := range cases {
+		klient := &updateCheckedTestClient{}
+
+		_, err := UpdateInstanceChecked(klient, tc.request, catalog)
+		if tc.expectedErr != nil {
+			if err != tc.expectedErr {
+				t.Errorf("%v: expected error %v, got %v", tc.name, tc.expectedErr, err)
+			}
+			if !IsPlanNotUpdatableError(err) {
+				t.Errorf("%v: expected a PlanNotUpdatableError, got %T", tc.name, err)
+			}
+		} else if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+
+		if e, a := tc.expectSent, klient.updates == 1; e != a {
+			t.Errorf("%v: expected request sent to be %v", tc.name, e)
+		}
+	}
+
+	_, err := UpdateInstanceChecked(&updateCheckedTestClient{}, &UpdateInstanceRequest{ServiceID: "unknown", PlanID: &large}, catalog)
+	if err == nil {
+		t.Error("expected an error for a service missing from the catalog")
+	}
+
+	klient := &updateCheckedTestClient{}
+	if _, err := UpdateInstanceChecked(klient, &UpdateInstanceRequest{ServiceID: "fixed-service", PlanID: &large}, nil); err != nil {
+		t.Errorf("unexpected error without a catalog: %v", err)
+	}
+	if e, a := 1, klient.updates; e != a {
+		t.Errorf("expected the request to be sent without a catalog, got %v updates", a)
+	}
+}
@@ -1,12 +1,18 @@
 package v2
 
+import "strings"
+
 // IsAsync returns true if the update request is being handled asynchronously.
 func (r *UpdateInstanceResponse) IsAsync() bool {
 	return r.Async
 }
 
-// GetOperationKey returns the operation key of the asynchronous request.
+// GetOperationKey returns the operation key of the asynchronous request, or
+// nil if r is nil.
 func (r *UpdateInstanceResponse) GetOperationKey() *OperationKey {
+	if r == nil {
+		return nil
+	}
 	return r.OperationKey
 }
 
@@ -14,3 +20,61 @@ func (r *UpdateInstanceResponse) GetOperationKey() *OperationKey {
 func (r *UpdateInstanceResponse) GetDashboardURL() *string {
 	return r.DashboardURL
 }
+
+// UpdateChangeKind is a set of flags describing what an update request
+// changes about an instance.
+type UpdateChangeKind int
+
+// NoChange indicates that the update request changes nothing.
+const NoChange UpdateChangeKind = 0
+
+// These are the kinds of change an update request can make.  They may be
+// combined, for example PlanChange|ParamChange.
+const (
+	// PlanChange indicates that the update request changes the plan.
+	PlanChange UpdateChangeKind = 1 << iota
+	// ParamChange indicates that the update request changes the parameters.
+	ParamChange
+	// ContextChange indicates that the update request changes the context.
+	ContextChange
+)
+
+// Has returns whether the change kind includes all of the given kinds.
+func (k UpdateChangeKind) Has(kind UpdateChangeKind) bool {
+	return k&kind == kind
+}
+
+func (k UpdateChangeKind) String() string {
+	if k == NoChange {
+		return "NoChange"
+	}
+
+	var kinds []string
+	if k.Has(PlanChange) {
+		kinds = append(kinds, "PlanChange")
+	}
+	if k.Has(ParamChange) {
+		kinds = append(kinds, "ParamChange")
+	}
+	if k.Has(ContextChange) {
+		kinds = append(kinds, "ContextChange")
+	}
+	return strings.Join(kinds, "|")
+}
+
+// ChangeKind returns what the update request changes about the instance.
+// A field is considered changed when it is set on the request, since unset
+// fields indicate that the client does not wish to update them.
+func (r *UpdateInstanceRequest) ChangeKind() UpdateChangeKind {
+	kind := NoChange
+	if r.PlanID != nil {
+		kind |= PlanChange
+	}
+	if r.Parameters != nil {
+		kind |= ParamChange
+	}
+	if r.Context != nil {
+		kind |= ContextChange
+	}
+	return kind
+}
@@ -0,0 +1,80 @@
+package v2
+
+import (
+	"testing"
+)
+
+func TestUpdateInstanceRequestChangeKind(t *testing.T) {
+	planID := "other-plan-id"
+	parameters := map[string]interface{}{"size": 1}
+	context := map[string]interface{}{"platform": "test"}
+
+	cases := []struct {
+		name     string
+		request  *UpdateInstanceRequest
+		expected UpdateChangeKind
+		str      string
+	}{
+		{
+			name:     "no-op",
+			request:  &UpdateInstanceRequest{},
+			expected: NoChange,
+			str:      "NoChange",
+		},
+		{
+			name:     "plan",
+			request:  &UpdateInstanceRequest{PlanID: &planID},
+			expected: PlanChange,
+			str:      "PlanChange",
+		},
+		{
+			name:     "parameters",
+			request:  &UpdateInstanceRequest{Parameters: parameters},
+			expected: ParamChange,
+			str:      "ParamChange",
+		},
+		{
+			name:     "context",
+			request:  &UpdateInstanceRequest{Context: context},
+			expected: ContextChange,
+			str:      "ContextChange",
+		},
+		{
+			name:     "plan and parameters",
+			request:  &UpdateInstanceRequest{PlanID: &planID, Parameters: parameters},
+			expected: PlanChange | ParamChange,
+			str:      "PlanChange|ParamChange",
+		},
+		{
+			name:     "plan and context",
+			request:  &UpdateInstanceRequest{PlanID: &planID, Context: context},
+			expected: PlanChange | ContextChange,
+			str:      "PlanChange|ContextChange",
+		},
+		{
+			name:     "parameters and context",
+			request:  &UpdateInstanceRequest{Parameters: parameters, Context: context},
+			expected: ParamChange | ContextChange,
+			str:      "ParamChange|ContextChange",
+		},
+		{
+			name:     "everything",
+			request:  &UpdateInstanceRequest{PlanID: &planID, Parameters: parameters, Context: context},
+			expected: PlanChange | ParamChange | ContextChange,
+			str:      "PlanChange|ParamChange|ContextChange",
+		},
+	}
+
+	for _, tc := range cases {
+		kind := tc.request.ChangeKind()
+		if e, a := tc.expected, kind; e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+		if e, a := tc.str, kind.String(); e != a {
+			t.Errorf("%v: expected %q, got %q", tc.name, e, a)
+		}
+		if e, a := tc.expected&PlanChange != 0, kind.Has(PlanChange); e != a {
+			t.Errorf("%v: expected Has(PlanChange) to be %v", tc.name, e)
+		}
+	}
+}
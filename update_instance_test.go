@@ -230,6 +230,47 @@ func TestUpdateInstanceInstance(t *testing.T) {
 			},
 			expectedResponse: successUpdateInstanceResponse(),
 		},
+		{
+			name:        "previous maintenance info",
+			version:     LatestAPIVersion(),
+			enableAlpha: true,
+			request: func() *UpdateInstanceRequest {
+				r := defaultUpdateInstanceRequest()
+				r.PreviousValues = &PreviousValues{
+					PlanID:          "previous-plan-id",
+					MaintenanceInfo: &MaintenanceInfo{Version: "1.0.0"},
+				}
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: `{"service_id":"test-service-id","plan_id":"test-plan-id","previous_values":{"plan_id":"previous-plan-id","maintenance_info":{"version":"1.0.0"}}}`,
+			},
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   successUpdateInstanceResponseBody,
+			},
+			expectedResponse: successUpdateInstanceResponse(),
+		},
+		{
+			name:    "previous maintenance info without alpha features",
+			version: LatestAPIVersion(),
+			request: func() *UpdateInstanceRequest {
+				r := defaultUpdateInstanceRequest()
+				r.PreviousValues = &PreviousValues{
+					PlanID:          "previous-plan-id",
+					MaintenanceInfo: &MaintenanceInfo{Version: "1.0.0"},
+				}
+				return r
+			}(),
+			httpChecks: httpChecks{
+				body: previousValuesUpdateInstanceRequestBody,
+			},
+			httpReaction: httpReaction{
+				status: http.StatusOK,
+				body:   successUpdateInstanceResponseBody,
+			},
+			expectedResponse: successUpdateInstanceResponse(),
+		},
 		{
 			name:                "originating identity included",
 			version:             Version2_13(),